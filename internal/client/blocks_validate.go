@@ -0,0 +1,344 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxBlocksPerMessage is Slack's limit on the number of blocks in a single
+// message.
+const MaxBlocksPerMessage = 50
+
+// Per-field limits from the Block Kit reference. Exceeding any of them makes
+// Slack reject the whole message with an opaque invalid_blocks error.
+const (
+	maxBlockIDLen      = 255
+	maxActionIDLen     = 255
+	maxFieldTextLen    = 2000
+	maxSectionFields   = 10
+	maxContextElements = 10
+	maxActionsElements = 25
+	maxImageURLLen     = 3000
+	maxAltTextLen      = 2000
+	maxButtonTextLen   = 75
+	maxMarkdownTextLen = 12000
+)
+
+// knownBlockTypes is the set of block types valid in messages.
+var knownBlockTypes = map[string]bool{
+	"actions":   true,
+	"context":   true,
+	"divider":   true,
+	"file":      true,
+	"header":    true,
+	"image":     true,
+	"input":     true,
+	"markdown":  true,
+	"rich_text": true,
+	"section":   true,
+	"table":     true,
+	"video":     true,
+}
+
+// BlockIssue is one problem found by ValidateBlocks, located by a JSON path
+// into the payload such as "blocks[3].text.text".
+type BlockIssue struct {
+	Path    string
+	Message string
+}
+
+func (i BlockIssue) String() string { return i.Path + ": " + i.Message }
+
+// BlockValidationError reports every issue found in a payload, one per line,
+// so a single run surfaces all problems instead of just the first.
+type BlockValidationError struct {
+	Issues []BlockIssue
+}
+
+func (e *BlockValidationError) Error() string {
+	lines := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		lines[i] = issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseBlocks decodes a Block Kit payload. It accepts either a bare JSON
+// array of blocks or an object with a "blocks" array (the shape Block Kit
+// Builder exports).
+func ParseBlocks(data []byte) ([]interface{}, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		var wrapper struct {
+			Blocks []interface{} `json:"blocks"`
+		}
+		if err := json.Unmarshal([]byte(trimmed), &wrapper); err != nil {
+			return nil, err
+		}
+		if wrapper.Blocks == nil {
+			return nil, fmt.Errorf(`object payload has no "blocks" array`)
+		}
+		return wrapper.Blocks, nil
+	}
+	var blocks []interface{}
+	if err := json.Unmarshal([]byte(trimmed), &blocks); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// ValidateBlocks checks a decoded Block Kit payload against Slack's message
+// rules without a round trip: known block types, required fields, the
+// 50-block and per-field text limits, block_id uniqueness across the
+// message and action_id uniqueness within each block. It returns a
+// *BlockValidationError listing every issue, or nil.
+func ValidateBlocks(blocks []interface{}) error {
+	v := &blockValidator{blockIDs: map[string]string{}}
+	if len(blocks) > MaxBlocksPerMessage {
+		v.addf("blocks", "%d blocks exceeds the %d-block message limit", len(blocks), MaxBlocksPerMessage)
+	}
+	for i, raw := range blocks {
+		v.block(fmt.Sprintf("blocks[%d]", i), raw)
+	}
+	if len(v.issues) == 0 {
+		return nil
+	}
+	return &BlockValidationError{Issues: v.issues}
+}
+
+type blockValidator struct {
+	issues    []BlockIssue
+	blockIDs  map[string]string // block_id -> first path seen
+	actionIDs map[string]string // action_id -> first path seen, reset per block
+}
+
+func (v *blockValidator) addf(path, format string, args ...interface{}) {
+	v.issues = append(v.issues, BlockIssue{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *blockValidator) block(path string, raw interface{}) {
+	b, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addf(path, "must be an object")
+		return
+	}
+	typ, ok := b["type"].(string)
+	if !ok || typ == "" {
+		v.addf(path+".type", "is required")
+		return
+	}
+	if !knownBlockTypes[typ] {
+		v.addf(path+".type", "unknown block type %q", typ)
+		return
+	}
+
+	if id, present := b["block_id"]; present {
+		s, ok := id.(string)
+		switch {
+		case !ok || s == "":
+			v.addf(path+".block_id", "must be a non-empty string")
+		case utf8.RuneCountInString(s) > maxBlockIDLen:
+			v.addf(path+".block_id", "exceeds %d chars", maxBlockIDLen)
+		case v.blockIDs[s] != "":
+			v.addf(path+".block_id", "duplicate block_id %q (also at %s)", s, v.blockIDs[s])
+		default:
+			v.blockIDs[s] = path
+		}
+	}
+
+	v.actionIDs = map[string]string{}
+	switch typ {
+	case "section":
+		_, hasText := b["text"]
+		_, hasFields := b["fields"]
+		if !hasText && !hasFields {
+			v.addf(path, "section requires text or fields")
+		}
+		if hasText {
+			v.textObject(path+".text", b["text"], maxSectionTextLen, false)
+		}
+		if hasFields {
+			fields := v.array(path+".fields", b["fields"], 1, maxSectionFields)
+			for i, f := range fields {
+				v.textObject(fmt.Sprintf("%s.fields[%d]", path, i), f, maxFieldTextLen, false)
+			}
+		}
+		if acc, ok := b["accessory"]; ok {
+			v.element(path+".accessory", acc)
+		}
+	case "header":
+		v.requireField(path, b, "text")
+		if t, ok := b["text"]; ok {
+			v.textObject(path+".text", t, maxHeaderTextLen, true)
+		}
+	case "image":
+		_, hasURL := b["image_url"]
+		_, hasFile := b["slack_file"]
+		if !hasURL && !hasFile {
+			v.addf(path, "image requires image_url or slack_file")
+		}
+		if hasURL {
+			v.stringField(path+".image_url", b["image_url"], maxImageURLLen)
+		}
+		v.requireField(path, b, "alt_text")
+		if alt, ok := b["alt_text"]; ok {
+			v.stringField(path+".alt_text", alt, maxAltTextLen)
+		}
+		if title, ok := b["title"]; ok {
+			v.textObject(path+".title", title, maxFieldTextLen, true)
+		}
+	case "context":
+		els := v.array(path+".elements", b["elements"], 1, maxContextElements)
+		for i, el := range els {
+			elPath := fmt.Sprintf("%s.elements[%d]", path, i)
+			m, ok := el.(map[string]interface{})
+			if ok && m["type"] == "image" {
+				v.contextImage(elPath, m)
+				continue
+			}
+			v.textObject(elPath, el, maxSectionTextLen, false)
+		}
+	case "actions":
+		els := v.array(path+".elements", b["elements"], 1, maxActionsElements)
+		for i, el := range els {
+			v.element(fmt.Sprintf("%s.elements[%d]", path, i), el)
+		}
+	case "input":
+		v.requireField(path, b, "label")
+		if label, ok := b["label"]; ok {
+			v.textObject(path+".label", label, maxFieldTextLen, true)
+		}
+		v.requireField(path, b, "element")
+		if el, ok := b["element"]; ok {
+			v.element(path+".element", el)
+		}
+		if hint, ok := b["hint"]; ok {
+			v.textObject(path+".hint", hint, maxFieldTextLen, true)
+		}
+	case "rich_text":
+		v.array(path+".elements", b["elements"], 1, 0)
+	case "video":
+		for _, f := range []string{"alt_text", "title", "thumbnail_url", "video_url"} {
+			v.requireField(path, b, f)
+		}
+	case "file":
+		v.requireField(path, b, "external_id")
+		v.requireField(path, b, "source")
+	case "markdown":
+		v.requireField(path, b, "text")
+		if t, ok := b["text"]; ok {
+			v.stringField(path+".text", t, maxMarkdownTextLen)
+		}
+	case "table":
+		v.array(path+".rows", b["rows"], 1, 0)
+	}
+}
+
+func (v *blockValidator) requireField(path string, obj map[string]interface{}, field string) {
+	if _, ok := obj[field]; !ok {
+		v.addf(path+"."+field, "is required")
+	}
+}
+
+// array checks that raw is an array with min..max items (max 0 = no limit)
+// and returns its items for further checks.
+func (v *blockValidator) array(path string, raw interface{}, min, max int) []interface{} {
+	if raw == nil {
+		v.addf(path, "is required")
+		return nil
+	}
+	items, ok := raw.([]interface{})
+	if !ok {
+		v.addf(path, "must be an array")
+		return nil
+	}
+	if len(items) < min {
+		v.addf(path, "must have at least %d item(s)", min)
+	}
+	if max > 0 && len(items) > max {
+		v.addf(path, "has %d items, exceeds the limit of %d", len(items), max)
+	}
+	return items
+}
+
+func (v *blockValidator) stringField(path string, raw interface{}, limit int) {
+	s, ok := raw.(string)
+	switch {
+	case !ok || s == "":
+		v.addf(path, "must be a non-empty string")
+	case limit > 0 && utf8.RuneCountInString(s) > limit:
+		v.addf(path, "exceeds %d chars", limit)
+	}
+}
+
+// textObject validates a {type, text} composition object. plainOnly
+// restricts the type to plain_text (headers, labels, button text).
+func (v *blockValidator) textObject(path string, raw interface{}, limit int, plainOnly bool) {
+	t, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addf(path, "must be a text object")
+		return
+	}
+	typ, _ := t["type"].(string)
+	switch {
+	case typ == "plain_text":
+	case typ == "mrkdwn" && !plainOnly:
+	case plainOnly:
+		v.addf(path+".type", "must be plain_text")
+	default:
+		v.addf(path+".type", "must be plain_text or mrkdwn")
+	}
+	v.stringField(path+".text", t["text"], limit)
+}
+
+func (v *blockValidator) contextImage(path string, img map[string]interface{}) {
+	_, hasURL := img["image_url"]
+	_, hasFile := img["slack_file"]
+	if !hasURL && !hasFile {
+		v.addf(path, "image requires image_url or slack_file")
+	}
+	v.requireField(path, img, "alt_text")
+}
+
+// element validates an interactive element (button, select, date picker,
+// ...). Only the fields every element shares are checked in depth; the
+// rest is left to Slack.
+func (v *blockValidator) element(path string, raw interface{}) {
+	el, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addf(path, "must be an object")
+		return
+	}
+	typ, ok := el["type"].(string)
+	if !ok || typ == "" {
+		v.addf(path+".type", "is required")
+		return
+	}
+	if id, present := el["action_id"]; present {
+		s, ok := id.(string)
+		switch {
+		case !ok || s == "":
+			v.addf(path+".action_id", "must be a non-empty string")
+		case utf8.RuneCountInString(s) > maxActionIDLen:
+			v.addf(path+".action_id", "exceeds %d chars", maxActionIDLen)
+		case v.actionIDs[s] != "":
+			v.addf(path+".action_id", "duplicate action_id %q (also at %s)", s, v.actionIDs[s])
+		default:
+			v.actionIDs[s] = path
+		}
+	}
+	switch typ {
+	case "button":
+		v.requireField(path, el, "text")
+		if t, ok := el["text"]; ok {
+			v.textObject(path+".text", t, maxButtonTextLen, true)
+		}
+		if u, ok := el["url"]; ok {
+			v.stringField(path+".url", u, maxImageURLLen)
+		}
+	case "image":
+		v.contextImage(path, el)
+	}
+}
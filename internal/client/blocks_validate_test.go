@@ -0,0 +1,130 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockIssues validates the JSON payload and returns the issues as
+// "path: message" strings.
+func blockIssues(t *testing.T, payload string) []string {
+	t.Helper()
+	blocks, err := ParseBlocks([]byte(payload))
+	require.NoError(t, err)
+	err = ValidateBlocks(blocks)
+	if err == nil {
+		return nil
+	}
+	var verr *BlockValidationError
+	require.True(t, errors.As(err, &verr))
+	var out []string
+	for _, issue := range verr.Issues {
+		out = append(out, issue.String())
+	}
+	return out
+}
+
+func TestParseBlocks(t *testing.T) {
+	blocks, err := ParseBlocks([]byte(`[{"type":"divider"}]`))
+	require.NoError(t, err)
+	assert.Len(t, blocks, 1)
+
+	blocks, err = ParseBlocks([]byte(` {"blocks":[{"type":"divider"},{"type":"divider"}]}`))
+	require.NoError(t, err)
+	assert.Len(t, blocks, 2)
+
+	_, err = ParseBlocks([]byte(`{"text":"hi"}`))
+	assert.Error(t, err)
+
+	_, err = ParseBlocks([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestValidateBlocks_Valid(t *testing.T) {
+	payload := `[
+		{"type":"header","text":{"type":"plain_text","text":"Deploy"}},
+		{"type":"section","block_id":"s1","text":{"type":"mrkdwn","text":"*done*"},
+		 "accessory":{"type":"button","action_id":"open","text":{"type":"plain_text","text":"Open"}}},
+		{"type":"section","fields":[{"type":"mrkdwn","text":"a"},{"type":"plain_text","text":"b"}]},
+		{"type":"divider"},
+		{"type":"context","elements":[{"type":"mrkdwn","text":"ci"},{"type":"image","image_url":"https://e.com/i.png","alt_text":"i"}]},
+		{"type":"image","image_url":"https://e.com/c.png","alt_text":"chart"},
+		{"type":"actions","elements":[
+			{"type":"button","action_id":"a","text":{"type":"plain_text","text":"A"}},
+			{"type":"button","action_id":"b","text":{"type":"plain_text","text":"B"}}]},
+		{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[{"type":"text","text":"x"}]}]}
+	]`
+	assert.Empty(t, blockIssues(t, payload))
+}
+
+func TestValidateBlocks_TextTooLong(t *testing.T) {
+	long := strings.Repeat("x", maxSectionTextLen+1)
+	payload := `[{"type":"divider"},{"type":"divider"},{"type":"divider"},
+		{"type":"section","text":{"type":"mrkdwn","text":"` + long + `"}}]`
+	assert.Equal(t, []string{"blocks[3].text.text: exceeds 3000 chars"}, blockIssues(t, payload))
+}
+
+func TestValidateBlocks_TooManyBlocks(t *testing.T) {
+	parts := make([]string, MaxBlocksPerMessage+1)
+	for i := range parts {
+		parts[i] = `{"type":"divider"}`
+	}
+	issues := blockIssues(t, "["+strings.Join(parts, ",")+"]")
+	assert.Equal(t, []string{"blocks: 51 blocks exceeds the 50-block message limit"}, issues)
+}
+
+func TestValidateBlocks_TypesAndRequiredFields(t *testing.T) {
+	tests := []struct {
+		name, block, want string
+	}{
+		{"missing type", `{"text":"x"}`, "blocks[0].type: is required"},
+		{"unknown type", `{"type":"sectoin"}`, `blocks[0].type: unknown block type "sectoin"`},
+		{"not an object", `"divider"`, "blocks[0]: must be an object"},
+		{"section empty", `{"type":"section"}`, "blocks[0]: section requires text or fields"},
+		{"header missing text", `{"type":"header"}`, "blocks[0].text: is required"},
+		{"header mrkdwn", `{"type":"header","text":{"type":"mrkdwn","text":"x"}}`, "blocks[0].text.type: must be plain_text"},
+		{"header too long", `{"type":"header","text":{"type":"plain_text","text":"` + strings.Repeat("h", 151) + `"}}`, "blocks[0].text.text: exceeds 150 chars"},
+		{"bad text type", `{"type":"section","text":{"type":"html","text":"x"}}`, "blocks[0].text.type: must be plain_text or mrkdwn"},
+		{"empty text", `{"type":"section","text":{"type":"mrkdwn","text":""}}`, "blocks[0].text.text: must be a non-empty string"},
+		{"image alt", `{"type":"image","image_url":"https://e.com/x.png"}`, "blocks[0].alt_text: is required"},
+		{"context empty", `{"type":"context","elements":[]}`, "blocks[0].elements: must have at least 1 item(s)"},
+		{"actions missing", `{"type":"actions"}`, "blocks[0].elements: is required"},
+		{"button text", `{"type":"actions","elements":[{"type":"button","action_id":"a"}]}`, "blocks[0].elements[0].text: is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := blockIssues(t, "["+tt.block+"]")
+			require.NotEmpty(t, issues)
+			assert.Equal(t, tt.want, issues[0])
+		})
+	}
+}
+
+func TestValidateBlocks_DuplicateIDs(t *testing.T) {
+	payload := `[
+		{"type":"actions","block_id":"b","elements":[
+			{"type":"button","action_id":"go","text":{"type":"plain_text","text":"1"}},
+			{"type":"button","action_id":"go","text":{"type":"plain_text","text":"2"}}]},
+		{"type":"actions","block_id":"b","elements":[
+			{"type":"button","action_id":"go","text":{"type":"plain_text","text":"3"}}]}
+	]`
+	assert.Equal(t, []string{
+		`blocks[0].elements[1].action_id: duplicate action_id "go" (also at blocks[0].elements[0])`,
+		`blocks[1].block_id: duplicate block_id "b" (also at blocks[0])`,
+	}, blockIssues(t, payload))
+}
+
+func TestValidateBlocks_ReportsAllIssues(t *testing.T) {
+	payload := `[{"type":"nope"},{"type":"section"},{"type":"header"}]`
+	issues := blockIssues(t, payload)
+	assert.Len(t, issues, 3)
+
+	blocks, err := ParseBlocks([]byte(payload))
+	require.NoError(t, err)
+	err = ValidateBlocks(blocks)
+	assert.Equal(t, strings.Join(issues, "\n"), err.Error())
+}
@@ -56,6 +56,16 @@ func (c *Client) ResolveMessageDestination(destination string) (string, error) {
 	return c.ResolveChannel(destination)
 }
 
+// ResolveUserID takes a user identifier and returns the user ID. It accepts
+// user IDs (U/W...), returned as-is, and handles with or without the leading
+// "@", looked up via the Slack API.
+func (c *Client) ResolveUserID(user string) (string, error) {
+	if IsUserID(user) {
+		return user, nil
+	}
+	return c.resolveUserHandle(user)
+}
+
 // IsChannelID returns true if the string looks like a Slack channel ID.
 // Channel IDs start with:
 //   - C = public channel
@@ -131,7 +141,11 @@ func (c *Client) resolveUserHandle(handle string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to list users: %w", err)
 	}
+	return matchUserHandle(users, handle)
+}
 
+// matchUserHandle finds the user a handle (without "@") names among users.
+func matchUserHandle(users []User, handle string) (string, error) {
 	var byName, byDisplay []User
 	for _, u := range users {
 		switch {
@@ -172,11 +186,19 @@ func (c *Client) lookupChannelByName(name string) (string, error) {
 		return "", fmt.Errorf("failed to list channels: %w", err)
 	}
 
+	if id := matchChannelName(channels, name); id != "" {
+		return id, nil
+	}
+
+	return "", fmt.Errorf("channel '%s' not found. Use 'slck channels list' to see available channels", name)
+}
+
+// matchChannelName returns the ID of the channel called name, or "".
+func matchChannelName(channels []Channel, name string) string {
 	for _, ch := range channels {
 		if strings.EqualFold(ch.Name, name) {
-			return ch.ID, nil
+			return ch.ID
 		}
 	}
-
-	return "", fmt.Errorf("channel '%s' not found. Use 'slck channels list' to see available channels", name)
+	return ""
 }
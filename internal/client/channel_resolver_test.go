@@ -366,3 +366,31 @@ func TestResolveMessageDestination_HandleAmbiguousAcrossPages(t *testing.T) {
 	assert.Contains(t, err.Error(), "ambiguous")
 	assert.Equal(t, 2, userListCalls)
 }
+
+func TestResolveUserID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users.list", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":      true,
+			"members": []map[string]interface{}{{"id": "U111", "name": "alice"}},
+		})
+	}))
+	defer server.Close()
+
+	c := NewWithConfig(server.URL, "test-token", nil)
+
+	id, err := c.ResolveUserID("U999")
+	require.NoError(t, err)
+	assert.Equal(t, "U999", id)
+
+	id, err = c.ResolveUserID("@alice")
+	require.NoError(t, err)
+	assert.Equal(t, "U111", id)
+
+	id, err = c.ResolveUserID("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "U111", id)
+
+	_, err = c.ResolveUserID("@nobody")
+	assert.Error(t, err)
+}
@@ -37,6 +37,7 @@ type Client struct {
 	httpClient *http.Client
 	token      string
 	baseURL    string
+	undoOf     int // journal entry the calls undo, see SetUndoOf
 }
 
 // NewBotClient creates a new Slack client using the bot token
@@ -120,6 +121,10 @@ type SlackResponse struct {
 }
 
 func (c *Client) get(endpoint string, params url.Values) (result []byte, err error) {
+	if body, skipped := c.skipForDryRun("GET", endpoint, params); skipped {
+		return body, nil
+	}
+
 	reqURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 	if params != nil {
 		reqURL += "?" + params.Encode()
@@ -148,19 +153,18 @@ func (c *Client) get(endpoint string, params url.Values) (result []byte, err err
 		return nil, err
 	}
 
-	var slackResp SlackResponse
-	if err := json.Unmarshal(body, &slackResp); err != nil {
+	if err := checkResponse(resp, body); err != nil {
 		return nil, err
 	}
 
-	if !slackResp.OK {
-		return nil, fmt.Errorf("slack API error: %s", slackResp.Error)
-	}
-
 	return body, nil
 }
 
 func (c *Client) post(endpoint string, data interface{}) (result []byte, err error) {
+	if body, skipped := c.skipForDryRun("POST", endpoint, data); skipped {
+		return body, nil
+	}
+
 	reqURL := fmt.Sprintf("%s/%s", c.baseURL, endpoint)
 
 	jsonData, err := json.Marshal(data)
@@ -191,15 +195,10 @@ func (c *Client) post(endpoint string, data interface{}) (result []byte, err err
 		return nil, err
 	}
 
-	var slackResp SlackResponse
-	if err := json.Unmarshal(body, &slackResp); err != nil {
+	if err := checkResponse(resp, body); err != nil {
 		return nil, err
 	}
 
-	if !slackResp.OK {
-		return nil, fmt.Errorf("slack API error: %s", slackResp.Error)
-	}
-
 	return body, nil
 }
 
@@ -230,9 +229,18 @@ type User struct {
 		DisplayName string `json:"display_name"`
 		StatusText  string `json:"status_text"`
 		StatusEmoji string `json:"status_emoji"`
+		Image72     string `json:"image_72,omitempty"`
 	} `json:"profile"`
 }
 
+// UserGroup represents a Slack user group (@handle mentioning several people)
+type UserGroup struct {
+	ID     string   `json:"id"`
+	Handle string   `json:"handle"`
+	Name   string   `json:"name"`
+	Users  []string `json:"users,omitempty"`
+}
+
 // Reaction represents an emoji reaction on a Slack message
 type Reaction struct {
 	Name  string   `json:"name"`
@@ -274,6 +282,7 @@ type BotProfile struct {
 // Message represents a Slack message
 type Message struct {
 	Type        string       `json:"type"`
+	Subtype     string       `json:"subtype,omitempty"`
 	User        string       `json:"user"`
 	Username    string       `json:"username,omitempty"`
 	BotProfile  BotProfile   `json:"bot_profile,omitempty"`
@@ -283,12 +292,57 @@ type Message struct {
 	TS          string       `json:"ts"`
 	ThreadTS    string       `json:"thread_ts,omitempty"`
 	ReplyCount  int          `json:"reply_count,omitempty"`
+	LatestReply string       `json:"latest_reply,omitempty"`
 	Edited      *Edited      `json:"edited,omitempty"`
 	Reactions   []Reaction   `json:"reactions,omitempty"`
 	Files       []File       `json:"files,omitempty"`
 	Blocks      []Block      `json:"blocks,omitempty"`
 	Attachments []Attachment `json:"attachments,omitempty"`
 	Permalink   string       `json:"permalink,omitempty"`
+	Metadata    *Metadata    `json:"metadata,omitempty"`
+
+	// raw holds the verbatim JSON captured during unmarshal, see Raw.
+	raw json.RawMessage
+}
+
+// UnmarshalJSON captures the raw bytes alongside the typed fields.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	m.raw = append(m.raw[:0], data...)
+	type alias Message
+	return json.Unmarshal(data, (*alias)(m))
+}
+
+// Raw returns the message exactly as Slack sent it, including fields the
+// struct does not model (replies, pinned_to, ...), or nil for a message
+// built in code. Later changes to the typed fields are not reflected.
+func (m Message) Raw() json.RawMessage {
+	return m.raw
+}
+
+// RawBlocks returns the message's blocks exactly as Slack sent them, so
+// they can be posted again without losing fields Block does not model.
+func (m Message) RawBlocks() []interface{} {
+	data := []byte(m.raw)
+	if len(data) == 0 {
+		var err error
+		if data, err = json.Marshal(m); err != nil {
+			return nil
+		}
+	}
+	var raw struct {
+		Blocks []interface{} `json:"blocks"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	return raw.Blocks
+}
+
+// Metadata is machine-readable data attached to a message: an event type
+// plus an arbitrary JSON payload.
+type Metadata struct {
+	EventType    string                 `json:"event_type"`
+	EventPayload map[string]interface{} `json:"event_payload"`
 }
 
 // Team represents workspace info
@@ -538,6 +592,27 @@ func (c *Client) GetUserInfo(userID string) (*User, error) {
 	return &result.User, nil
 }
 
+// ListUserGroups returns the workspace's user groups with their member IDs.
+// Requires the usergroups:read scope.
+func (c *Client) ListUserGroups() ([]UserGroup, error) {
+	params := url.Values{}
+	params.Set("include_users", "true")
+
+	body, err := c.get("usergroups.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		UserGroups []UserGroup `json:"usergroups"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return result.UserGroups, nil
+}
+
 // OpenDM opens (or returns the existing) direct-message conversation with a user
 // and returns its IM channel ID (D...). It wraps conversations.open, which is
 // idempotent: calling it for a user you already have a DM with returns the same
@@ -568,10 +643,35 @@ func (c *Client) OpenDM(userID string) (string, error) {
 // Text can be empty if blocks are provided (Slack API allows this).
 // The unfurl parameter controls whether link previews are shown (unfurl_links and unfurl_media).
 func (c *Client) SendMessage(channel, text, threadTS string, blocks []interface{}, unfurl bool) (*Message, error) {
+	return c.SendMessageWithOptions(channel, text, threadTS, blocks, unfurl, PostOptions{})
+}
+
+// PostOptions holds the optional chat.postMessage arguments that most
+// callers leave unset.
+type PostOptions struct {
+	// Username, IconEmoji and IconURL customize the author shown for the
+	// message (requires the chat:write.customize scope on a bot token).
+	Username  string
+	IconEmoji string
+	IconURL   string
+	// ReplyBroadcast also shows a thread reply in the channel.
+	ReplyBroadcast bool
+	// NoUnfurlMedia disables media previews even when links unfurl.
+	NoUnfurlMedia bool
+	// Parse sets Slack's text parsing mode: "full" or "none".
+	Parse string
+	// NoMrkdwn sends the text without mrkdwn formatting.
+	NoMrkdwn bool
+	// Metadata is attached to the message for other tools to read.
+	Metadata *Metadata
+}
+
+// SendMessageWithOptions is SendMessage with the optional arguments in opts.
+func (c *Client) SendMessageWithOptions(channel, text, threadTS string, blocks []interface{}, unfurl bool, opts PostOptions) (*Message, error) {
 	data := map[string]interface{}{
 		"channel":      channel,
 		"unfurl_links": unfurl,
-		"unfurl_media": unfurl,
+		"unfurl_media": unfurl && !opts.NoUnfurlMedia,
 	}
 	// Only include text if non-empty (Slack allows omitting text when blocks are provided)
 	if text != "" {
@@ -583,6 +683,27 @@ func (c *Client) SendMessage(channel, text, threadTS string, blocks []interface{
 	if len(blocks) > 0 {
 		data["blocks"] = blocks
 	}
+	if opts.Username != "" {
+		data["username"] = opts.Username
+	}
+	if opts.IconEmoji != "" {
+		data["icon_emoji"] = opts.IconEmoji
+	}
+	if opts.IconURL != "" {
+		data["icon_url"] = opts.IconURL
+	}
+	if opts.ReplyBroadcast {
+		data["reply_broadcast"] = true
+	}
+	if opts.Parse != "" {
+		data["parse"] = opts.Parse
+	}
+	if opts.NoMrkdwn {
+		data["mrkdwn"] = false
+	}
+	if opts.Metadata != nil {
+		data["metadata"] = opts.Metadata
+	}
 
 	body, err := c.post("chat.postMessage", data)
 	if err != nil {
@@ -620,6 +741,23 @@ func (c *Client) UpdateMessage(channel, ts, text string, blocks []interface{}, u
 	return err
 }
 
+// RestoreMessage sets a message back to exactly the given text and blocks;
+// unlike UpdateMessage, empty blocks remove any the message has gained.
+func (c *Client) RestoreMessage(channel, ts, text string, blocks []interface{}) error {
+	if blocks == nil {
+		blocks = []interface{}{}
+	}
+	data := map[string]interface{}{
+		"channel": channel,
+		"ts":      ts,
+		"text":    text,
+		"blocks":  blocks,
+	}
+
+	_, err := c.post("chat.update", data)
+	return err
+}
+
 // DeleteMessage deletes a message
 func (c *Client) DeleteMessage(channel, ts string) error {
 	data := map[string]interface{}{
@@ -631,21 +769,56 @@ func (c *Client) DeleteMessage(channel, ts string) error {
 	return err
 }
 
-// GetChannelHistory returns message history (handles pagination to reach requested limit)
+// GetMessage returns one message, top-level or a thread reply. The window
+// is narrowed to ts itself, so a reply is found without paging its thread;
+// Slack may still include the thread's parent, hence the scan.
+func (c *Client) GetMessage(channel, ts string) (*Message, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("ts", ts)
+	params.Set("oldest", ts)
+	params.Set("latest", ts)
+	params.Set("inclusive", "true")
+	params.Set("limit", "1")
+	params.Set("include_all_metadata", "true")
+
+	body, err := c.get("conversations.replies", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Messages []Message `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	for i := range result.Messages {
+		if result.Messages[i].TS == ts {
+			return &result.Messages[i], nil
+		}
+	}
+	return nil, fmt.Errorf("message %s not found in %s", ts, channel)
+}
+
+// GetChannelHistory returns message history (handles pagination to reach requested limit).
+// A limit of 0 or less fetches every page. Message metadata is always included.
 func (c *Client) GetChannelHistory(channel string, limit int, oldest, latest string) ([]Message, error) {
 	var allMessages []Message
 	cursor := ""
 	remaining := limit
+	unlimited := limit <= 0
 
-	for remaining > 0 {
+	for unlimited || remaining > 0 {
 		params := url.Values{}
 		params.Set("channel", channel)
 		// Request up to 200 at a time (Slack recommended max)
 		batchSize := remaining
-		if batchSize > 200 {
+		if unlimited || batchSize > 200 {
 			batchSize = 200
 		}
 		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		params.Set("include_all_metadata", "true")
 		if oldest != "" {
 			params.Set("oldest", oldest)
 		}
@@ -681,29 +854,32 @@ func (c *Client) GetChannelHistory(channel string, limit int, oldest, latest str
 	}
 
 	// Trim to exact limit if we got more
-	if len(allMessages) > limit {
+	if !unlimited && len(allMessages) > limit {
 		allMessages = allMessages[:limit]
 	}
 
 	return allMessages, nil
 }
 
-// GetThreadReplies returns replies to a thread (handles pagination to reach requested limit)
+// GetThreadReplies returns replies to a thread (handles pagination to reach requested limit).
+// A limit of 0 or less fetches every page. Message metadata is always included.
 func (c *Client) GetThreadReplies(channel, threadTS string, limit int, oldest string) ([]Message, error) {
 	var allMessages []Message
 	cursor := ""
 	remaining := limit
+	unlimited := limit <= 0
 
-	for remaining > 0 {
+	for unlimited || remaining > 0 {
 		params := url.Values{}
 		params.Set("channel", channel)
 		params.Set("ts", threadTS)
 		// Request up to 200 at a time (Slack recommended max)
 		batchSize := remaining
-		if batchSize > 200 {
+		if unlimited || batchSize > 200 {
 			batchSize = 200
 		}
 		params.Set("limit", fmt.Sprintf("%d", batchSize))
+		params.Set("include_all_metadata", "true")
 		if oldest != "" {
 			params.Set("oldest", oldest)
 		}
@@ -736,7 +912,7 @@ func (c *Client) GetThreadReplies(channel, threadTS string, limit int, oldest st
 	}
 
 	// Trim to exact limit if we got more
-	if len(allMessages) > limit {
+	if !unlimited && len(allMessages) > limit {
 		allMessages = allMessages[:limit]
 	}
 
@@ -755,6 +931,29 @@ func (c *Client) AddReaction(channel, timestamp, name string) error {
 	return err
 }
 
+// GetReactions returns a message with every reaction on it, including the
+// full list of users for each emoji.
+func (c *Client) GetReactions(channel, timestamp string) (*Message, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("timestamp", timestamp)
+	params.Set("full", "true")
+
+	body, err := c.get("reactions.get", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Message Message `json:"message"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Message, nil
+}
+
 // RemoveReaction removes an emoji reaction
 func (c *Client) RemoveReaction(channel, timestamp, name string) error {
 	data := map[string]interface{}{
@@ -938,9 +1137,19 @@ type UploadURLResponse struct {
 
 // GetUploadURLExternal gets a presigned URL for file upload
 func (c *Client) GetUploadURLExternal(filename string, length int64) (*UploadURLResponse, error) {
+	return c.GetSnippetUploadURL(filename, length, "")
+}
+
+// GetSnippetUploadURL gets a presigned URL for uploading a text snippet.
+// snippetType is a Slack file type such as "go" or "python" and selects the
+// syntax highlighting; empty uploads a plain file.
+func (c *Client) GetSnippetUploadURL(filename string, length int64, snippetType string) (*UploadURLResponse, error) {
 	params := url.Values{}
 	params.Set("filename", filename)
 	params.Set("length", fmt.Sprintf("%d", length))
+	if snippetType != "" {
+		params.Set("snippet_type", snippetType)
+	}
 
 	body, err := c.get("files.getUploadURLExternal", params)
 	if err != nil {
@@ -957,6 +1166,15 @@ func (c *Client) GetUploadURLExternal(filename string, length int64) (*UploadURL
 
 // UploadFileToURL uploads file bytes to the presigned URL
 func (c *Client) UploadFileToURL(uploadURL string, data io.Reader) error {
+	if dryRunOut != nil {
+		n, err := io.Copy(io.Discard, data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(dryRunOut, "[dry-run] POST %s (%d bytes)\n", uploadURL, n)
+		return nil
+	}
+
 	req, err := http.NewRequest("POST", uploadURL, data)
 	if err != nil {
 		return err
@@ -1000,6 +1218,54 @@ func (c *Client) CompleteUploadExternal(files []CompleteUploadExternalFile, chan
 	return err
 }
 
+// FileUpload is one file to upload: the name and title it gets in Slack
+// (Title defaults to Name), its content and size, and for snippets the
+// Slack file type that selects syntax highlighting.
+type FileUpload struct {
+	Name        string
+	Title       string
+	Content     io.Reader
+	Size        int64
+	SnippetType string
+}
+
+// UploadFile uploads one file and shares it in channel, or in the thread
+// threadTS, with comment posted alongside. It returns the file's ID.
+func (c *Client) UploadFile(f FileUpload, channel, threadTS, comment string) (string, error) {
+	ids, err := c.UploadFiles([]FileUpload{f}, channel, threadTS, comment)
+	if err != nil {
+		return "", err
+	}
+	return ids[0], nil
+}
+
+// UploadFiles uploads files and shares them together, as one message, in
+// channel or the thread threadTS. It returns the files' IDs.
+func (c *Client) UploadFiles(files []FileUpload, channel, threadTS, comment string) ([]string, error) {
+	completed := make([]CompleteUploadExternalFile, 0, len(files))
+	ids := make([]string, 0, len(files))
+	for _, f := range files {
+		resp, err := c.GetSnippetUploadURL(f.Name, f.Size, f.SnippetType)
+		if err != nil {
+			return nil, fmt.Errorf("get upload URL for %s: %w", f.Name, err)
+		}
+		if err := c.UploadFileToURL(resp.UploadURL, f.Content); err != nil {
+			return nil, fmt.Errorf("upload %s: %w", f.Name, err)
+		}
+		title := f.Title
+		if title == "" {
+			title = f.Name
+		}
+		completed = append(completed, CompleteUploadExternalFile{ID: resp.FileID, Title: title})
+		ids = append(ids, resp.FileID)
+	}
+
+	if err := c.CompleteUploadExternal(completed, channel, threadTS, comment); err != nil {
+		return nil, fmt.Errorf("complete upload: %w", err)
+	}
+	return ids, nil
+}
+
 // --- File Info & Download Methods ---
 
 // GetFileInfo returns metadata for a file by ID
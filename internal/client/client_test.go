@@ -2,6 +2,8 @@ package client
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -331,6 +333,61 @@ func TestClient_SendMessage_WithThread(t *testing.T) {
 	}
 }
 
+func TestClient_SendMessageWithOptions_Identity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+
+		if reqBody["username"] != "alice" {
+			t.Errorf("expected username alice, got %v", reqBody["username"])
+		}
+		if reqBody["icon_url"] != "https://example.com/a.png" {
+			t.Errorf("expected icon_url, got %v", reqBody["icon_url"])
+		}
+		if _, ok := reqBody["icon_emoji"]; ok {
+			t.Errorf("expected icon_emoji to be omitted, got %v", reqBody["icon_emoji"])
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	opts := PostOptions{Username: "alice", IconURL: "https://example.com/a.png"}
+	if _, err := client.SendMessageWithOptions("C123", "hi", "", nil, true, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_SendMessageWithOptions_Delivery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+
+		want := map[string]interface{}{
+			"reply_broadcast": true,
+			"unfurl_links":    true,
+			"unfurl_media":    false,
+			"parse":           "full",
+			"mrkdwn":          false,
+		}
+		for key, value := range want {
+			if reqBody[key] != value {
+				t.Errorf("expected %s=%v, got %v", key, value, reqBody[key])
+			}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	opts := PostOptions{ReplyBroadcast: true, NoUnfurlMedia: true, Parse: "full", NoMrkdwn: true}
+	if _, err := client.SendMessageWithOptions("C123", "hi", "1111111111.111111", nil, true, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestClient_SendMessage_WithBlocks(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var reqBody map[string]interface{}
@@ -1607,3 +1664,252 @@ func TestClient_GetPermalink_Error(t *testing.T) {
 		t.Fatal("expected an error for a not-OK response, got nil")
 	}
 }
+
+func TestGetChannelHistory_UnlimitedFetchesEveryPage(t *testing.T) {
+	pages := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		if r.URL.Query().Get("limit") != "200" {
+			t.Errorf("expected batch limit 200, got %s", r.URL.Query().Get("limit"))
+		}
+		next := ""
+		if pages < 3 {
+			next = fmt.Sprintf("page%d", pages+1)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":                true,
+			"messages":          []map[string]interface{}{{"ts": fmt.Sprintf("1700000000.00000%d", pages)}},
+			"response_metadata": map[string]string{"next_cursor": next},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	messages, err := client.GetChannelHistory("C123", 0, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 3 || pages != 3 {
+		t.Fatalf("expected 3 messages over 3 pages, got %d over %d", len(messages), pages)
+	}
+}
+
+func TestCompareTS(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1700000001.000009", "1700000001.000010", -1},
+		{"1700000010.000000", "999999999.999999", 1},
+		{"1700000001.5", "1700000001.500000", 0},
+		{"", "0.000001", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareTS(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareTS(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestMessage_RawKeepsUnmodeledFields(t *testing.T) {
+	in := `{"type":"message","subtype":"channel_join","user":"U1","text":"joined","ts":"1.000001","replies":[{"user":"U2","ts":"2.000001"}]}`
+	var m Message
+	if err := json.Unmarshal([]byte(in), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.User != "U1" {
+		t.Errorf("expected user U1, got %q", m.User)
+	}
+	if string(m.Raw()) != in {
+		t.Errorf("expected Raw to keep the JSON as received, got %s", m.Raw())
+	}
+}
+
+func TestMessage_MarshalReflectsChanges(t *testing.T) {
+	var m Message
+	if err := json.Unmarshal([]byte(`{"type":"message","text":"hi","ts":"1.000001"}`), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.Permalink = "https://example.slack.com/archives/C1/p1000001"
+
+	out, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(out), m.Permalink) {
+		t.Errorf("expected the permalink set after decoding in %s", out)
+	}
+}
+
+func TestGetChannelHistory_IncludesMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("include_all_metadata") != "true" {
+			t.Errorf("expected include_all_metadata=true, got %q", r.URL.Query().Get("include_all_metadata"))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{{
+				"ts": "1700000000.000001",
+				"metadata": map[string]interface{}{
+					"event_type":    "deploy_started",
+					"event_payload": map[string]interface{}{"service": "api"},
+				},
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	messages, err := client.GetChannelHistory("C123", 10, "", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 1 || messages[0].Metadata == nil {
+		t.Fatalf("expected one message with metadata, got %+v", messages)
+	}
+	if messages[0].Metadata.EventType != "deploy_started" || messages[0].Metadata.EventPayload["service"] != "api" {
+		t.Errorf("unexpected metadata: %+v", messages[0].Metadata)
+	}
+}
+
+func TestClient_SendMessageWithOptions_Metadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody struct {
+			Metadata Metadata `json:"metadata"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&reqBody)
+		if reqBody.Metadata.EventType != "deploy_started" || reqBody.Metadata.EventPayload["version"] != "1.4.2" {
+			t.Errorf("unexpected metadata: %+v", reqBody.Metadata)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	opts := PostOptions{Metadata: &Metadata{
+		EventType:    "deploy_started",
+		EventPayload: map[string]interface{}{"version": "1.4.2"},
+	}}
+	if _, err := client.SendMessageWithOptions("C123", "Deploying", "", nil, true, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_GetReactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/reactions.get" {
+			t.Errorf("expected reactions.get, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("full") != "true" {
+			t.Errorf("expected full=true, got %q", r.URL.Query().Get("full"))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"message": map[string]interface{}{
+				"ts":        "1700000000.000001",
+				"reactions": []map[string]interface{}{{"name": "thumbsup", "count": 2, "users": []string{"U1", "U2"}}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	msg, err := client.GetReactions("C123", "1700000000.000001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(msg.Reactions) != 1 || msg.Reactions[0].Count != 2 || len(msg.Reactions[0].Users) != 2 {
+		t.Errorf("unexpected reactions: %+v", msg.Reactions)
+	}
+}
+
+func TestClient_ListUserGroups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/usergroups.list" {
+			t.Errorf("expected usergroups.list, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("include_users") != "true" {
+			t.Errorf("expected include_users=true, got %q", r.URL.Query().Get("include_users"))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"usergroups": []map[string]interface{}{
+				{"id": "S1", "handle": "releasemgrs", "name": "Release managers", "users": []string{"U1", "U2"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	groups, err := client.ListUserGroups()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(groups) != 1 || groups[0].Handle != "releasemgrs" || len(groups[0].Users) != 2 {
+		t.Errorf("unexpected groups: %+v", groups)
+	}
+}
+
+func TestClient_GetSnippetUploadURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("snippet_type") != "go" || q.Get("filename") != "trace.txt" || q.Get("length") != "42" {
+			t.Errorf("unexpected query: %s", r.URL.RawQuery)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "upload_url": "https://upload", "file_id": "F1"})
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	resp, err := client.GetSnippetUploadURL("trace.txt", 42, "go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.FileID != "F1" {
+		t.Errorf("expected file ID F1, got %q", resp.FileID)
+	}
+}
+
+func TestClient_UploadFilesSharesTogether(t *testing.T) {
+	var uploaded []string
+	var complete map[string]interface{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files.getUploadURLExternal":
+			name := r.URL.Query().Get("filename")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "upload_url": server.URL + "/upload", "file_id": "F" + name})
+		case "/upload":
+			body, _ := io.ReadAll(r.Body)
+			uploaded = append(uploaded, string(body))
+		case "/files.completeUploadExternal":
+			_ = json.NewDecoder(r.Body).Decode(&complete)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewWithConfig(server.URL, "test-token", nil)
+	ids, err := client.UploadFiles([]FileUpload{
+		{Name: "a.txt", Content: strings.NewReader("aa"), Size: 2},
+		{Name: "b.txt", Title: "Bee", Content: strings.NewReader("bbb"), Size: 3},
+	}, "C123", "1700000000.000100", "both files")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(ids, ",") != "Fa.txt,Fb.txt" || strings.Join(uploaded, ",") != "aa,bbb" {
+		t.Errorf("unexpected uploads: ids %v, bodies %v", ids, uploaded)
+	}
+	files, _ := complete["files"].([]interface{})
+	if len(files) != 2 || complete["initial_comment"] != "both files" || complete["thread_ts"] != "1700000000.000100" {
+		t.Fatalf("expected one completion sharing both files, got %v", complete)
+	}
+	if title := files[0].(map[string]interface{})["title"]; title != "a.txt" {
+		t.Errorf("expected the title to default to the name, got %v", title)
+	}
+	if title := files[1].(map[string]interface{})["title"]; title != "Bee" {
+		t.Errorf("expected title Bee, got %v", title)
+	}
+}
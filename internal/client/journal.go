@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+)
+
+// actionLog receives the entries commands record, set by the root command.
+// nil = nothing is recorded (tests, --dry-run).
+var actionLog *journal.Log
+
+// SetJournal makes Record append entries to l. A nil l turns recording off
+// again.
+func SetJournal(l *journal.Log) {
+	actionLog = l
+}
+
+// SetUndoOf marks the entries this client records from now on as undoing
+// journal entry id.
+func (c *Client) SetUndoOf(id int) {
+	c.undoOf = id
+}
+
+// Record appends e to the journal, stamped with the client's token type.
+// Commands call it once each change has succeeded, so a failed write only
+// warns.
+func (c *Client) Record(e journal.Entry) {
+	if actionLog == nil {
+		return
+	}
+	e.Token = c.tokenType()
+	e.UndoOf = c.undoOf
+	if _, err := actionLog.Append(e); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not record %s in the journal: %v\n", e.Action, err)
+	}
+}
+
+// tokenType names the kind of token the client uses, from its prefix.
+func (c *Client) tokenType() string {
+	switch {
+	case strings.HasPrefix(c.token, "xoxb-"):
+		return "bot"
+	case strings.HasPrefix(c.token, "xoxp-"):
+		return "user"
+	default:
+		return ""
+	}
+}
+
+// MessageEntry starts the journal entry for an update or delete of a
+// message, holding its text and blocks from before the change. Call it
+// before changing the message and Record the entry after. The lookup only
+// happens while journaling and is best-effort: HasPrevious stays false when
+// it fails.
+func (c *Client) MessageEntry(action, channel, ts string) journal.Entry {
+	e := journal.Entry{Action: action, Channel: channel, TS: ts}
+	if actionLog == nil {
+		return e
+	}
+	m, err := c.GetMessage(channel, ts)
+	if err != nil {
+		return e
+	}
+	e.Previous, e.PreviousBlocks, e.HasPrevious = m.Text, m.RawBlocks(), true
+	return e
+}
+
+// ChannelEntry starts the journal entry for a topic or purpose change,
+// holding the value from before it, like MessageEntry.
+func (c *Client) ChannelEntry(action, channel string) journal.Entry {
+	e := journal.Entry{Action: action, Channel: channel}
+	if actionLog == nil {
+		return e
+	}
+	ch, err := c.GetChannelInfo(channel)
+	if err != nil {
+		return e
+	}
+	switch action {
+	case journal.ActionTopic:
+		e.Previous, e.HasPrevious = ch.Topic.Value, true
+	case journal.ActionPurpose:
+		e.Previous, e.HasPrevious = ch.Purpose.Value, true
+	}
+	return e
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+)
+
+func TestJournal_EntriesCapturePreviousValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/conversations.replies":
+			resp["messages"] = []map[string]interface{}{{
+				"type": "message", "text": "old text", "ts": "1700000000.000100",
+				"blocks": []map[string]interface{}{{"type": "divider", "block_id": "b1"}},
+			}}
+		case "/conversations.info":
+			resp["channel"] = map[string]interface{}{"id": "C111", "topic": map[string]interface{}{"value": "old topic"}}
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	log := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	SetJournal(log)
+	defer SetJournal(nil)
+	c := NewWithConfig(server.URL, "xoxb-test", nil)
+
+	update := c.MessageEntry(journal.ActionUpdate, "C111", "1700000000.000100")
+	update.Text = "new text"
+	c.Record(update)
+	topic := c.ChannelEntry(journal.ActionTopic, "C111")
+	c.Record(topic)
+	c.SetUndoOf(1)
+	c.Record(journal.Entry{Action: journal.ActionReact, Channel: "C111", TS: "1700000000.000100", Reaction: "eyes"})
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, journal.ActionUpdate, entries[0].Action)
+	assert.Equal(t, "bot", entries[0].Token)
+	assert.Equal(t, "old text", entries[0].Previous)
+	assert.Equal(t, "new text", entries[0].Text)
+	assert.True(t, entries[0].HasPrevious)
+	require.Len(t, entries[0].PreviousBlocks, 1)
+
+	assert.Equal(t, "old topic", entries[1].Previous)
+	assert.True(t, entries[1].HasPrevious)
+
+	assert.Equal(t, 1, entries[2].UndoOf)
+}
+
+func TestJournal_FailedLookupLeavesPreviousUnknown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "channel_not_found"})
+	}))
+	defer server.Close()
+
+	SetJournal(journal.New(filepath.Join(t.TempDir(), "journal.jsonl")))
+	defer SetJournal(nil)
+	c := NewWithConfig(server.URL, "xoxp-test", nil)
+
+	e := c.MessageEntry(journal.ActionUpdate, "C404", "1700000000.000100")
+	assert.False(t, e.HasPrevious)
+	e = c.ChannelEntry(journal.ActionPurpose, "C404")
+	assert.False(t, e.HasPrevious)
+}
+
+func TestJournal_ClientCallsDoNotRecord(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1700000000.000100"})
+	}))
+	defer server.Close()
+
+	log := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	SetJournal(log)
+	defer SetJournal(nil)
+	c := NewWithConfig(server.URL, "xoxb-test", nil)
+
+	_, err := c.SendMessage("C111", "hello", "", nil, true)
+	require.NoError(t, err)
+	require.NoError(t, c.UpdateMessage("C111", "1700000000.000100", "new", nil, true))
+	require.NoError(t, c.DeleteMessage("C111", "1700000000.000100"))
+	require.NoError(t, c.SetChannelTopic("C111", "topic"))
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries, "commands record explicitly")
+	assert.Equal(t, []string{"/chat.postMessage", "/chat.update", "/chat.delete", "/conversations.setTopic"}, paths,
+		"no previous-value lookups on the way")
+}
+
+func TestGetMessage_FetchesOnlyThatMessage(t *testing.T) {
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = flattenParams(r.URL.Query()).(map[string]string)
+		// A reply comes back after its thread's parent
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "text": "parent", "ts": "1700000000.000100"},
+				{"type": "message", "text": "reply", "ts": "1700000000.000200", "thread_ts": "1700000000.000100"},
+			},
+			"response_metadata": map[string]interface{}{"next_cursor": "more"},
+		})
+	}))
+	defer server.Close()
+
+	c := NewWithConfig(server.URL, "xoxb-test", nil)
+	m, err := c.GetMessage("C111", "1700000000.000200")
+	require.NoError(t, err)
+	assert.Equal(t, "reply", m.Text)
+	assert.Equal(t, "1", query["limit"])
+	assert.Equal(t, "1700000000.000200", query["oldest"])
+	assert.Equal(t, "1700000000.000200", query["latest"])
+	assert.Equal(t, "true", query["inclusive"])
+
+	_, err = c.GetMessage("C111", "1700000000.000300")
+	assert.ErrorContains(t, err, "message 1700000000.000300 not found")
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// nameMentionRegex matches a plain-text @handle or #channel. The mention
+// must start the text or follow whitespace or an opening bracket or quote,
+// so email addresses and URL fragments are left alone.
+var nameMentionRegex = regexp.MustCompile(`(^|[\s(\[{"'])([@#])([A-Za-z0-9][\w.\-]*)`)
+
+// verbatimRegex matches the parts of a message that are never linked: code
+// blocks, inline code and existing <…> tokens.
+var verbatimRegex = regexp.MustCompile("(?s)```.*?```|`[^`\n]*`|<[^<>\n]*>")
+
+// specialMentions are the broadcast keywords Slack only honours as tokens.
+var specialMentions = map[string]string{
+	"here":     "<!here>",
+	"channel":  "<!channel>",
+	"everyone": "<!everyone>",
+}
+
+// NameLinker rewrites plain-text @handles and #channels into the tokens
+// Slack renders as mentions. The workspace's users, user groups and
+// channels are each listed at most once, on first use.
+type NameLinker struct {
+	client   *Client
+	users    []User
+	groups   []UserGroup
+	channels []Channel
+	loaded   map[string]bool
+}
+
+// NewNameLinker creates a linker backed by the given client.
+func NewNameLinker(c *Client) *NameLinker {
+	return &NameLinker{client: c, loaded: map[string]bool{}}
+}
+
+// Link replaces @here, @channel and @everyone with their broadcast tokens,
+// @handle with a user (or, failing that, a user group) mention and #name
+// with a channel link. Text inside code and existing tokens is untouched.
+// It returns the rewritten text and, in order of appearance, the mentions
+// that matched nothing or more than one user.
+func (l *NameLinker) Link(text string) (string, []string, error) {
+	var b strings.Builder
+	var unresolved []string
+	seen := map[string]bool{}
+
+	last := 0
+	spans := verbatimRegex.FindAllStringIndex(text, -1)
+	spans = append(spans, []int{len(text), len(text)})
+	for _, span := range spans {
+		linked, missed, err := l.linkPlain(text[last:span[0]])
+		if err != nil {
+			return "", nil, err
+		}
+		b.WriteString(linked)
+		b.WriteString(text[span[0]:span[1]])
+		for _, m := range missed {
+			if !seen[m] {
+				seen[m] = true
+				unresolved = append(unresolved, m)
+			}
+		}
+		last = span[1]
+	}
+	return b.String(), unresolved, nil
+}
+
+// linkPlain links the mentions in text that contains no code or tokens.
+func (l *NameLinker) linkPlain(text string) (string, []string, error) {
+	var out strings.Builder
+	var missed []string
+	last := 0
+	for _, m := range nameMentionRegex.FindAllStringSubmatchIndex(text, -1) {
+		sigil, name := text[m[4]:m[5]], text[m[6]:m[7]]
+		// Sentence punctuation is not part of the name: "ask @bob."
+		trimmed := strings.TrimRight(name, ".-")
+		end := m[7] - (len(name) - len(trimmed))
+		if sigil == "#" && strings.Trim(trimmed, "0123456789") == "" {
+			continue // an issue number such as #123, not a channel
+		}
+
+		token, err := l.token(sigil, trimmed)
+		if err != nil {
+			return "", nil, err
+		}
+		if token == "" {
+			missed = append(missed, sigil+trimmed)
+			continue
+		}
+		out.WriteString(text[last:m[4]])
+		out.WriteString(token)
+		last = end
+	}
+	out.WriteString(text[last:])
+	return out.String(), missed, nil
+}
+
+// token returns the Slack token for one mention, or "" when it matches
+// nothing.
+func (l *NameLinker) token(sigil, name string) (string, error) {
+	if sigil == "#" {
+		if err := l.load("channels"); err != nil {
+			return "", err
+		}
+		if id := matchChannelName(l.channels, name); id != "" {
+			return "<#" + id + ">", nil
+		}
+		return "", nil
+	}
+
+	if tok, ok := specialMentions[strings.ToLower(name)]; ok {
+		return tok, nil
+	}
+	if err := l.load("users"); err != nil {
+		return "", err
+	}
+	if id, err := matchUserHandle(l.users, name); err == nil {
+		return "<@" + id + ">", nil
+	}
+	if err := l.load("groups"); err != nil {
+		return "", err
+	}
+	for _, g := range l.groups {
+		if strings.EqualFold(g.Handle, name) {
+			return "<!subteam^" + g.ID + ">", nil
+		}
+	}
+	return "", nil
+}
+
+// load lists one kind of name the first time it is needed.
+func (l *NameLinker) load(kind string) error {
+	if l.loaded[kind] {
+		return nil
+	}
+	var err error
+	switch kind {
+	case "users":
+		if l.users, err = l.client.ListAllUsers(); err != nil {
+			return fmt.Errorf("failed to list users: %w", err)
+		}
+	case "channels":
+		if l.channels, err = l.client.ListChannels("public_channel,private_channel", false, 1000); err != nil {
+			return fmt.Errorf("failed to list channels: %w", err)
+		}
+	case "groups":
+		// User groups need usergroups:read; without it only people link
+		if l.groups, err = l.client.ListUserGroups(); err != nil && !IsSlackError(err, "missing_scope") {
+			return fmt.Errorf("failed to list user groups: %w", err)
+		}
+	}
+	l.loaded[kind] = true
+	return nil
+}
@@ -0,0 +1,103 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nameServer answers the three listings NameLinker uses and counts calls.
+func nameServer(t *testing.T, calls map[string]int, groupsErr string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/users.list":
+			resp["members"] = []map[string]interface{}{
+				{"id": "U111", "name": "alice"},
+				{"id": "U222", "name": "bob", "profile": map[string]interface{}{"display_name": "Bobby"}},
+				{"id": "U333", "name": "sam1", "profile": map[string]interface{}{"display_name": "sam"}},
+				{"id": "U444", "name": "sam2", "profile": map[string]interface{}{"display_name": "sam"}},
+			}
+		case "/conversations.list":
+			resp["channels"] = []map[string]interface{}{{"id": "C111", "name": "deploys"}}
+		case "/usergroups.list":
+			if groupsErr != "" {
+				resp = map[string]interface{}{"ok": false, "error": groupsErr}
+				break
+			}
+			resp["usergroups"] = []map[string]interface{}{{"id": "S111", "handle": "oncall"}}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return NewWithConfig(server.URL, "test-token", nil)
+}
+
+func TestNameLinker_Link(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		expected   string
+		unresolved []string
+	}{
+		{"user", "@alice please check #deploys", "<@U111> please check <#C111>", nil},
+		{"display name", "thanks @Bobby!", "thanks <@U222>!", nil},
+		{"user group", "paging @oncall", "paging <!subteam^S111>", nil},
+		{"broadcast", "@here and @channel", "<!here> and <!channel>", nil},
+		{"trailing period", "ask @alice.", "ask <@U111>.", nil},
+		{"brackets", "(cc @alice)", "(cc <@U111>)", nil},
+		{"email and url", "mail alice@example.com or see https://x.io/#deploys", "mail alice@example.com or see https://x.io/#deploys", nil},
+		{"issue number", "fixes #123", "fixes #123", nil},
+		{"code", "run `@alice` then\n```\n#deploys\n```", "run `@alice` then\n```\n#deploys\n```", nil},
+		{"existing token", "<@U999> and <https://x.io|#deploys>", "<@U999> and <https://x.io|#deploys>", nil},
+		{"unresolved", "@nobody @sam #nowhere @nobody", "@nobody @sam #nowhere @nobody", []string{"@nobody", "@sam", "#nowhere"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := nameServer(t, map[string]int{}, "")
+			got, unresolved, err := NewNameLinker(c).Link(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+			assert.Equal(t, tt.unresolved, unresolved)
+		})
+	}
+}
+
+func TestNameLinker_ListsEachKindOnce(t *testing.T) {
+	calls := map[string]int{}
+	c := nameServer(t, calls, "")
+	linker := NewNameLinker(c)
+
+	_, _, err := linker.Link("@alice @bob")
+	require.NoError(t, err)
+	_, _, err = linker.Link("@alice #deploys #deploys")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"/users.list": 1, "/conversations.list": 1}, calls)
+}
+
+func TestNameLinker_MissingGroupScope(t *testing.T) {
+	c := nameServer(t, map[string]int{}, "missing_scope")
+
+	got, unresolved, err := NewNameLinker(c).Link("@oncall @alice")
+	require.NoError(t, err)
+	assert.Equal(t, "@oncall <@U111>", got)
+	assert.Equal(t, []string{"@oncall"}, unresolved)
+}
+
+func TestNameLinker_ListError(t *testing.T) {
+	c := nameServer(t, map[string]int{}, "internal_error")
+
+	_, _, err := NewNameLinker(c).Link("@oncall")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list user groups")
+}
@@ -0,0 +1,926 @@
+package client
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Block Kit per-block limits the Markdown converter respects. Slack rejects
+// the whole message with invalid_blocks when any one of these is exceeded.
+const (
+	maxHeaderTextLen  = 150
+	maxSectionTextLen = 3000
+	maxListIndent     = 8
+)
+
+var (
+	mdFenceRegex     = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})(.*)$")
+	mdHeadingRegex   = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?[ \t]*$`)
+	mdClosingHashes  = regexp.MustCompile(`(?:^|[ \t]+)#+$`)
+	mdRuleRegex      = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdQuoteRegex     = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	mdListItemRegex  = regexp.MustCompile(`^([ \t]*)([-*+]|\d{1,9}[.)])(?:[ \t]+(.*))?$`)
+	mdSetextH1Regex  = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	mdSetextH2Regex  = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	mdTableDelimRe   = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdContextRegex   = regexp.MustCompile(`^ {0,3}<(sub|small)>(.*)</(?:sub|small)>[ \t]*$`)
+	mdImageRegex     = regexp.MustCompile(`^ {0,3}!\[([^\]]*)\]\(([^)\s]+)(?:[ \t]+"[^"]*")?\)[ \t]*$`)
+	mdSlackTokenRe   = regexp.MustCompile(`^(?:@[UW][A-Z0-9]+|#[CGD][A-Z0-9]+(?:\|[^>]*)?|![a-z]+(?:\^[A-Z0-9]+)?(?:\|[^>]*)?)$`)
+	mdAutolinkRegex  = regexp.MustCompile(`^(?:https?|mailto):[^\s<>]+$`)
+	mdLinkDestRegex  = regexp.MustCompile(`^\(\s*<?([^\s<>()]+)>?(?:\s+"[^"]*")?\s*\)`)
+	mdLinkTitleStrip = regexp.MustCompile(`\s+"[^"]*"$`)
+)
+
+// MarkdownToBlocks converts CommonMark (plus GFM tables and strikethrough)
+// into Block Kit blocks suitable for chat.postMessage / chat.update:
+//
+//   - # and ## headings become header blocks; deeper headings become bold
+//     section text
+//   - paragraphs become mrkdwn sections, merged while they fit in one block
+//   - bullet and ordered lists become rich_text lists (nesting preserved)
+//   - fenced code and tables become rich_text_preformatted
+//   - block quotes become rich_text_quote
+//   - thematic breaks (---) become dividers
+//   - a paragraph that is only an image becomes an image block
+//   - a <sub>…</sub> or <small>…</small> paragraph becomes a context block
+//
+// Text is split so no block exceeds Slack's per-block limits (3000 chars of
+// section text, 150 chars of header text). The caller owns the 50-blocks-
+// per-message limit, which depends on how the result is posted.
+func MarkdownToBlocks(md string) []interface{} {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	c := &mdConverter{lines: strings.Split(md, "\n")}
+	c.run()
+	return c.blocks
+}
+
+type mdConverter struct {
+	lines  []string
+	pos    int
+	blocks []interface{}
+
+	// para accumulates mrkdwn from consecutive paragraphs (and minor
+	// headings) so they share one section block until the next addition
+	// would overflow maxSectionTextLen. Keeps long reports under the
+	// per-message block limit.
+	para        string
+	paraHeading bool
+}
+
+func (c *mdConverter) run() {
+	for c.pos < len(c.lines) {
+		line := c.lines[c.pos]
+		switch {
+		case isBlankLine(line):
+			c.pos++
+		case mdFenceRegex.MatchString(line):
+			c.codeBlock()
+		case mdHeadingRegex.MatchString(line):
+			c.atxHeading()
+		case mdRuleRegex.MatchString(line):
+			c.emit(map[string]interface{}{"type": "divider"})
+			c.pos++
+		case mdQuoteRegex.MatchString(line):
+			c.quote()
+		case mdListItemRegex.MatchString(line):
+			c.list()
+		case c.tableStartsAt(c.pos):
+			c.table()
+		default:
+			c.paragraph()
+		}
+	}
+	c.flushParagraph()
+}
+
+// emit appends a non-paragraph block, first flushing any pending paragraph
+// text so document order is preserved.
+func (c *mdConverter) emit(block map[string]interface{}) {
+	c.flushParagraph()
+	c.blocks = append(c.blocks, block)
+}
+
+func (c *mdConverter) flushParagraph() {
+	if c.para == "" {
+		return
+	}
+	c.blocks = append(c.blocks, mrkdwnSection(c.para))
+	c.para = ""
+	c.paraHeading = false
+}
+
+// addParagraph merges mrkdwn into the pending section block. A heading is
+// followed by a single newline so it hugs the paragraph it introduces.
+func (c *mdConverter) addParagraph(mrkdwn string, heading bool) {
+	if mrkdwn == "" {
+		return
+	}
+	if utf8.RuneCountInString(mrkdwn) > maxSectionTextLen {
+		c.flushParagraph()
+		for _, chunk := range splitText(mrkdwn, maxSectionTextLen) {
+			c.blocks = append(c.blocks, mrkdwnSection(chunk))
+		}
+		return
+	}
+	sep := "\n\n"
+	if c.paraHeading {
+		sep = "\n"
+	}
+	if c.para != "" && utf8.RuneCountInString(c.para)+len(sep)+utf8.RuneCountInString(mrkdwn) > maxSectionTextLen {
+		c.flushParagraph()
+	}
+	if c.para != "" {
+		c.para += sep
+	}
+	c.para += mrkdwn
+	c.paraHeading = heading
+}
+
+func (c *mdConverter) codeBlock() {
+	m := mdFenceRegex.FindStringSubmatch(c.lines[c.pos])
+	fence := m[1]
+	c.pos++
+	var code []string
+	for c.pos < len(c.lines) {
+		line := c.lines[c.pos]
+		trimmed := strings.TrimLeft(line, " ")
+		if len(line)-len(trimmed) <= 3 && strings.HasPrefix(trimmed, fence[:1]) &&
+			strings.TrimRight(trimmed, fence[:1]+" \t") == "" &&
+			len(strings.TrimRight(trimmed, " \t")) >= len(fence) {
+			c.pos++
+			break
+		}
+		code = append(code, line)
+		c.pos++
+	}
+	c.preformatted(strings.Join(code, "\n"))
+}
+
+// preformatted emits text as one or more rich_text_preformatted blocks,
+// splitting on line boundaries so each stays within the section limit.
+func (c *mdConverter) preformatted(text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	for _, chunk := range splitText(text, maxSectionTextLen) {
+		c.emit(map[string]interface{}{
+			"type": "rich_text",
+			"elements": []interface{}{
+				map[string]interface{}{
+					"type":     "rich_text_preformatted",
+					"elements": []interface{}{map[string]interface{}{"type": "text", "text": chunk}},
+				},
+			},
+		})
+	}
+}
+
+func (c *mdConverter) atxHeading() {
+	m := mdHeadingRegex.FindStringSubmatch(c.lines[c.pos])
+	c.pos++
+	text := strings.TrimSpace(mdClosingHashes.ReplaceAllString(m[2], ""))
+	c.heading(len(m[1]), text)
+}
+
+func (c *mdConverter) heading(level int, text string) {
+	if text == "" {
+		return
+	}
+	spans := parseInline(text)
+	plain := spansToPlain(spans)
+	if level <= 2 && utf8.RuneCountInString(plain) <= maxHeaderTextLen {
+		c.emit(map[string]interface{}{
+			"type": "header",
+			"text": map[string]interface{}{
+				"type":  "plain_text",
+				"text":  plain,
+				"emoji": true,
+			},
+		})
+		return
+	}
+	c.addParagraph("*"+EscapeMrkdwn(plain)+"*", true)
+}
+
+func (c *mdConverter) quote() {
+	var lines []string
+	for c.pos < len(c.lines) {
+		m := mdQuoteRegex.FindStringSubmatch(c.lines[c.pos])
+		if m == nil {
+			break
+		}
+		lines = append(lines, m[1])
+		c.pos++
+	}
+	elements := spansToRichText(parseInline(joinParagraphLines(lines)))
+	if len(elements) == 0 {
+		return
+	}
+	c.emit(map[string]interface{}{
+		"type": "rich_text",
+		"elements": []interface{}{
+			map[string]interface{}{"type": "rich_text_quote", "elements": elements},
+		},
+	})
+}
+
+type mdListItem struct {
+	indent  int
+	ordered bool
+	number  int
+	text    string
+}
+
+func (c *mdConverter) list() {
+	var items []mdListItem
+	for c.pos < len(c.lines) {
+		line := c.lines[c.pos]
+		if m := mdListItemRegex.FindStringSubmatch(line); m != nil && !mdRuleRegex.MatchString(line) {
+			item := mdListItem{indent: indentWidth(m[1]), text: m[3]}
+			if marker := m[2]; marker[0] >= '0' && marker[0] <= '9' {
+				item.ordered = true
+				item.number, _ = strconv.Atoi(marker[:len(marker)-1])
+			}
+			items = append(items, item)
+			c.pos++
+			continue
+		}
+		if isBlankLine(line) {
+			// A blank line only continues a (loose) list when the next
+			// non-blank line is another item or indented continuation.
+			next := c.pos + 1
+			for next < len(c.lines) && isBlankLine(c.lines[next]) {
+				next++
+			}
+			if next < len(c.lines) && (mdListItemRegex.MatchString(c.lines[next]) || indentWidth(leadingSpace(c.lines[next])) >= 2) {
+				c.pos = next
+				continue
+			}
+			break
+		}
+		if c.startsBlock(c.pos) {
+			break
+		}
+		// Continuation line of the previous item.
+		last := &items[len(items)-1]
+		last.text = joinParagraphLines([]string{last.text, strings.TrimSpace(line)})
+		c.pos++
+	}
+	c.emitList(items)
+}
+
+// emitList groups items into consecutive rich_text_list elements, one per
+// run of identical (depth, style), which is how Slack models nested lists.
+func (c *mdConverter) emitList(items []mdListItem) {
+	var elements []interface{}
+	var stack []int
+	counts := make([]int, maxListIndent+1)
+	var cur map[string]interface{}
+	curDepth, curOrdered := -1, false
+
+	for _, item := range items {
+		for len(stack) > 0 && item.indent < stack[len(stack)-1] {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 || item.indent > stack[len(stack)-1] {
+			stack = append(stack, item.indent)
+		}
+		depth := len(stack) - 1
+		if depth > maxListIndent {
+			depth = maxListIndent
+		}
+
+		if cur == nil || depth != curDepth || item.ordered != curOrdered {
+			for d := depth + 1; d < len(counts); d++ {
+				counts[d] = 0
+			}
+			if depth == curDepth && item.ordered != curOrdered {
+				counts[depth] = 0
+			}
+			style := "bullet"
+			if item.ordered {
+				style = "ordered"
+				if counts[depth] == 0 && item.number > 1 {
+					counts[depth] = item.number - 1
+				}
+			}
+			cur = map[string]interface{}{
+				"type":     "rich_text_list",
+				"style":    style,
+				"indent":   depth,
+				"elements": []interface{}{},
+			}
+			if item.ordered && counts[depth] > 0 {
+				cur["offset"] = counts[depth]
+			}
+			elements = append(elements, cur)
+			curDepth, curOrdered = depth, item.ordered
+		}
+
+		inline := spansToRichText(parseInline(item.text))
+		if len(inline) == 0 {
+			inline = []interface{}{map[string]interface{}{"type": "text", "text": " "}}
+		}
+		cur["elements"] = append(cur["elements"].([]interface{}), map[string]interface{}{
+			"type":     "rich_text_section",
+			"elements": inline,
+		})
+		counts[depth]++
+	}
+	if len(elements) == 0 {
+		return
+	}
+	c.emit(map[string]interface{}{"type": "rich_text", "elements": elements})
+}
+
+func (c *mdConverter) tableStartsAt(i int) bool {
+	return i+1 < len(c.lines) && strings.Contains(c.lines[i], "|") &&
+		strings.Contains(c.lines[i+1], "|") && mdTableDelimRe.MatchString(c.lines[i+1])
+}
+
+// table renders a GFM pipe table as an aligned monospace grid. Block Kit
+// has no general-purpose table block for messages, and a preformatted grid
+// reads correctly on every client.
+func (c *mdConverter) table() {
+	rows := [][]string{splitTableRow(c.lines[c.pos])}
+	c.pos += 2 // header + delimiter row
+	for c.pos < len(c.lines) && !isBlankLine(c.lines[c.pos]) && strings.Contains(c.lines[c.pos], "|") {
+		rows = append(rows, splitTableRow(c.lines[c.pos]))
+		c.pos++
+	}
+
+	cols := 0
+	for _, r := range rows {
+		if len(r) > cols {
+			cols = len(r)
+		}
+	}
+	widths := make([]int, cols)
+	for _, r := range rows {
+		for i, cell := range r {
+			if w := utf8.RuneCountInString(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	var b strings.Builder
+	writeRow := func(r []string) {
+		var cells []string
+		for i := 0; i < cols; i++ {
+			cell := ""
+			if i < len(r) {
+				cell = r[i]
+			}
+			cells = append(cells, cell+strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		b.WriteString(strings.TrimRight(strings.Join(cells, " | "), " "))
+		b.WriteString("\n")
+	}
+	writeRow(rows[0])
+	var seps []string
+	for _, w := range widths {
+		seps = append(seps, strings.Repeat("-", w))
+	}
+	b.WriteString(strings.Join(seps, "-+-"))
+	b.WriteString("\n")
+	for _, r := range rows[1:] {
+		writeRow(r)
+	}
+	c.preformatted(strings.TrimRight(b.String(), "\n"))
+}
+
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cur strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cur.WriteByte('|')
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, spansToPlain(parseInline(strings.TrimSpace(cur.String()))))
+			cur.Reset()
+			continue
+		}
+		cur.WriteByte(line[i])
+	}
+	return append(cells, spansToPlain(parseInline(strings.TrimSpace(cur.String()))))
+}
+
+func (c *mdConverter) paragraph() {
+	var lines []string
+	for c.pos < len(c.lines) {
+		line := c.lines[c.pos]
+		if isBlankLine(line) {
+			break
+		}
+		if len(lines) > 0 {
+			// Setext headings: the underline turns the paragraph so far
+			// into a heading. Checked before thematic breaks because
+			// "---" under text is a heading, not a rule.
+			if mdSetextH1Regex.MatchString(line) {
+				c.pos++
+				c.heading(1, joinParagraphLines(lines))
+				return
+			}
+			if mdSetextH2Regex.MatchString(line) {
+				c.pos++
+				c.heading(2, joinParagraphLines(lines))
+				return
+			}
+			if c.startsBlock(c.pos) {
+				break
+			}
+		}
+		lines = append(lines, line)
+		c.pos++
+	}
+
+	if len(lines) == 1 {
+		if m := mdImageRegex.FindStringSubmatch(lines[0]); m != nil {
+			alt := m[1]
+			if alt == "" {
+				alt = "image"
+			}
+			c.emit(map[string]interface{}{"type": "image", "image_url": m[2], "alt_text": alt})
+			return
+		}
+		if m := mdContextRegex.FindStringSubmatch(lines[0]); m != nil {
+			if text := spansToMrkdwn(parseInline(strings.TrimSpace(m[2]))); text != "" {
+				c.emit(map[string]interface{}{
+					"type":     "context",
+					"elements": []interface{}{map[string]interface{}{"type": "mrkdwn", "text": text}},
+				})
+			}
+			return
+		}
+	}
+	c.addParagraph(spansToMrkdwn(parseInline(joinParagraphLines(lines))), false)
+}
+
+// startsBlock reports whether line i begins a construct that interrupts a
+// paragraph or list item.
+func (c *mdConverter) startsBlock(i int) bool {
+	line := c.lines[i]
+	return mdFenceRegex.MatchString(line) || mdHeadingRegex.MatchString(line) ||
+		mdRuleRegex.MatchString(line) || mdQuoteRegex.MatchString(line) ||
+		mdListItemRegex.MatchString(line) || c.tableStartsAt(i)
+}
+
+// joinParagraphLines applies CommonMark line-break rules: a soft break
+// becomes a space, a hard break (two trailing spaces or a trailing
+// backslash) becomes a newline.
+func joinParagraphLines(lines []string) string {
+	var b strings.Builder
+	for i, line := range lines {
+		hard := strings.HasSuffix(line, "  ") || strings.HasSuffix(line, `\`)
+		line = strings.TrimSpace(line)
+		if hard {
+			line = strings.TrimSuffix(line, `\`)
+		}
+		b.WriteString(line)
+		if i < len(lines)-1 {
+			if hard {
+				b.WriteString("\n")
+			} else {
+				b.WriteString(" ")
+			}
+		}
+	}
+	return b.String()
+}
+
+func isBlankLine(s string) bool { return strings.TrimSpace(s) == "" }
+
+func leadingSpace(s string) string { return s[:len(s)-len(strings.TrimLeft(s, " \t"))] }
+
+// indentWidth measures leading whitespace with tabs expanded to 4 columns.
+func indentWidth(ws string) int {
+	n := 0
+	for _, r := range ws {
+		if r == '\t' {
+			n += 4 - n%4
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+// splitText breaks s into chunks of at most limit runes, preferring the
+// last newline, then the last space, before the limit.
+func splitText(s string, limit int) []string {
+	var chunks []string
+	for utf8.RuneCountInString(s) > limit {
+		cut := runeOffset(s, limit)
+		head := s[:cut]
+		if idx := strings.LastIndex(head, "\n"); idx > 0 {
+			chunks = append(chunks, s[:idx])
+			s = s[idx+1:]
+			continue
+		}
+		if idx := strings.LastIndex(head, " "); idx > 0 {
+			chunks = append(chunks, s[:idx])
+			s = s[idx+1:]
+			continue
+		}
+		chunks = append(chunks, head)
+		s = s[cut:]
+	}
+	if s != "" {
+		chunks = append(chunks, s)
+	}
+	return chunks
+}
+
+// runeOffset returns the byte offset of the n-th rune in s.
+func runeOffset(s string, n int) int {
+	i := 0
+	for off := range s {
+		if i == n {
+			return off
+		}
+		i++
+	}
+	return len(s)
+}
+
+func mrkdwnSection(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "section",
+		"text": map[string]interface{}{"type": "mrkdwn", "text": text},
+	}
+}
+
+// --- Inline parsing ---
+
+// mdStyle is the set of inline formatting flags active on a span.
+type mdStyle struct {
+	bold, italic, strike bool
+}
+
+// mdSpan is one run of inline text with uniform formatting.
+type mdSpan struct {
+	text  string
+	url   string // non-empty for links
+	code  bool
+	slack bool // verbatim Slack token such as <@U123> or <!here>
+	style mdStyle
+}
+
+func parseInline(s string) []mdSpan { return parseInlineStyled(s, mdStyle{}) }
+
+func parseInlineStyled(s string, st mdStyle) []mdSpan {
+	var spans []mdSpan
+	var buf strings.Builder
+	flush := func() {
+		if buf.Len() > 0 {
+			spans = append(spans, mdSpan{text: buf.String(), style: st})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			buf.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case ch == '`':
+			n := runLength(s, i, '`')
+			if end := findCodeClose(s, i+n, n); end >= 0 {
+				flush()
+				code := s[i+n : end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				spans = append(spans, mdSpan{text: code, code: true, style: st})
+				i = end + n
+				continue
+			}
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+
+		case ch == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, dest, end, ok := parseLink(s, i+1); ok {
+				flush()
+				if text == "" {
+					text = dest
+				}
+				spans = append(spans, mdSpan{text: text, url: dest, style: st})
+				i = end
+				continue
+			}
+
+		case ch == '[':
+			if text, dest, end, ok := parseLink(s, i); ok {
+				flush()
+				for _, sp := range parseInlineStyled(text, st) {
+					if !sp.slack {
+						sp.url = dest
+					}
+					spans = append(spans, sp)
+				}
+				i = end
+				continue
+			}
+
+		case ch == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 1 {
+				tok := s[i+1 : i+end]
+				switch {
+				case mdSlackTokenRe.MatchString(tok):
+					flush()
+					spans = append(spans, mdSpan{text: "<" + tok + ">", slack: true, style: st})
+					i += end + 1
+					continue
+				case mdAutolinkRegex.MatchString(tok):
+					flush()
+					spans = append(spans, mdSpan{text: tok, url: tok, style: st})
+					i += end + 1
+					continue
+				}
+			}
+
+		case ch == '*' || ch == '_' || ch == '~':
+			if inner, style, end, ok := matchEmphasis(s, i, st); ok {
+				flush()
+				spans = append(spans, parseInlineStyled(inner, style)...)
+				i = end
+				continue
+			}
+			n := runLength(s, i, ch)
+			buf.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		buf.WriteByte(ch)
+		i++
+	}
+	flush()
+	return spans
+}
+
+// matchEmphasis tries to open an emphasis run at s[i]. It returns the inner
+// text, the resulting style, and the index just past the closing run.
+func matchEmphasis(s string, i int, st mdStyle) (string, mdStyle, int, bool) {
+	ch := s[i]
+	n := runLength(s, i, ch)
+	if n > 3 || (ch == '~' && n > 2) {
+		return "", st, 0, false
+	}
+	open := i + n
+	// Left-flanking: the run must be followed by non-whitespace, and an
+	// underscore run must not sit inside a word (snake_case stays literal).
+	if open >= len(s) || isSpaceByte(s[open]) {
+		return "", st, 0, false
+	}
+	if ch == '_' && i > 0 && isWordByte(s[i-1]) {
+		return "", st, 0, false
+	}
+
+	for j := open + 1; j < len(s); j++ {
+		if s[j] == '`' {
+			// Delimiters inside code spans don't close emphasis.
+			m := runLength(s, j, '`')
+			if end := findCodeClose(s, j+m, m); end >= 0 {
+				j = end + m - 1
+			}
+			continue
+		}
+		if s[j] != ch {
+			continue
+		}
+		r := runLength(s, j, ch)
+		if r != n || isSpaceByte(s[j-1]) {
+			j += r - 1
+			continue
+		}
+		if ch == '_' && j+r < len(s) && isWordByte(s[j+r]) {
+			j += r - 1
+			continue
+		}
+		style := st
+		switch {
+		case ch == '~':
+			style.strike = true
+		case n == 1:
+			style.italic = true
+		case n == 2:
+			style.bold = true
+		default:
+			style.bold, style.italic = true, true
+		}
+		return s[open:j], style, j + r, true
+	}
+	return "", st, 0, false
+}
+
+// parseLink parses "[text](dest)" starting at the '[' at s[i].
+func parseLink(s string, i int) (text, dest string, end int, ok bool) {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				m := mdLinkDestRegex.FindStringSubmatch(s[j+1:])
+				if m == nil {
+					return "", "", 0, false
+				}
+				dest = mdLinkTitleStrip.ReplaceAllString(m[1], "")
+				return s[i+1 : j], dest, j + 1 + len(m[0]), true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+func findCodeClose(s string, from, n int) int {
+	for j := from; j < len(s); j++ {
+		if s[j] != '`' {
+			continue
+		}
+		r := runLength(s, j, '`')
+		if r == n {
+			return j
+		}
+		j += r - 1
+	}
+	return -1
+}
+
+func runLength(s string, i int, ch byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == ch {
+		n++
+	}
+	return n
+}
+
+func isASCIIPunct(b byte) bool {
+	return b < utf8.RuneSelf && unicode.IsPunct(rune(b)) || strings.IndexByte("$+<=>^`|~", b) >= 0
+}
+
+func isSpaceByte(b byte) bool { return b == ' ' || b == '\t' || b == '\n' }
+
+func isWordByte(b byte) bool {
+	return b >= utf8.RuneSelf || b == '_' || (b >= '0' && b <= '9') || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+// --- Inline emitters ---
+
+// EscapeMrkdwn escapes the three characters Slack reserves for control
+// sequences in mrkdwn text.
+func EscapeMrkdwn(s string) string {
+	s = strings.ReplaceAll(s, "&", "&amp;")
+	s = strings.ReplaceAll(s, "<", "&lt;")
+	return strings.ReplaceAll(s, ">", "&gt;")
+}
+
+// CodeBlock wraps text in a mrkdwn code block. The text is escaped, and any
+// ``` inside it is defused so it cannot end the block early.
+func CodeBlock(text string) string {
+	text = strings.ReplaceAll(EscapeMrkdwn(text), "```", "'''")
+	return "```\n" + text + "\n```"
+}
+
+// spansToMrkdwn renders spans as Slack mrkdwn. Formatting markers are only
+// toggled at style transitions so a bold run containing a link stays one
+// *…* range instead of fragmenting into adjacent markers.
+func spansToMrkdwn(spans []mdSpan) string {
+	var b strings.Builder
+	var open []byte
+	want := func(st mdStyle) []byte {
+		var m []byte
+		if st.bold {
+			m = append(m, '*')
+		}
+		if st.italic {
+			m = append(m, '_')
+		}
+		if st.strike {
+			m = append(m, '~')
+		}
+		return m
+	}
+	for _, sp := range spans {
+		next := want(sp.style)
+		common := 0
+		for common < len(open) && common < len(next) && open[common] == next[common] {
+			common++
+		}
+		for k := len(open) - 1; k >= common; k-- {
+			b.WriteByte(open[k])
+		}
+		b.Write(next[common:])
+		open = next
+
+		switch {
+		case sp.slack:
+			b.WriteString(sp.text)
+		case sp.code:
+			t := "`" + EscapeMrkdwn(sp.text) + "`"
+			if sp.url != "" {
+				t = "<" + sp.url + "|" + t + ">"
+			}
+			b.WriteString(t)
+		case sp.url != "":
+			if sp.text == sp.url {
+				b.WriteString("<" + sp.url + ">")
+			} else {
+				b.WriteString("<" + sp.url + "|" + EscapeMrkdwn(sp.text) + ">")
+			}
+		default:
+			b.WriteString(EscapeMrkdwn(sp.text))
+		}
+	}
+	for k := len(open) - 1; k >= 0; k-- {
+		b.WriteByte(open[k])
+	}
+	return b.String()
+}
+
+// spansToRichText renders spans as rich_text inline elements, mapping
+// Slack tokens to their typed element (user, channel, broadcast, usergroup).
+func spansToRichText(spans []mdSpan) []interface{} {
+	var out []interface{}
+	for _, sp := range spans {
+		if sp.text == "" {
+			continue
+		}
+		if sp.slack {
+			out = append(out, slackTokenElement(sp.text))
+			continue
+		}
+		el := map[string]interface{}{"type": "text", "text": sp.text}
+		if sp.url != "" {
+			el["type"] = "link"
+			el["url"] = sp.url
+		}
+		style := map[string]interface{}{}
+		if sp.style.bold {
+			style["bold"] = true
+		}
+		if sp.style.italic {
+			style["italic"] = true
+		}
+		if sp.style.strike {
+			style["strike"] = true
+		}
+		if sp.code {
+			style["code"] = true
+		}
+		if len(style) > 0 {
+			el["style"] = style
+		}
+		out = append(out, el)
+	}
+	return out
+}
+
+func slackTokenElement(tok string) map[string]interface{} {
+	inner := strings.TrimSuffix(strings.TrimPrefix(tok, "<"), ">")
+	if idx := strings.IndexByte(inner, '|'); idx >= 0 {
+		inner = inner[:idx]
+	}
+	switch {
+	case strings.HasPrefix(inner, "@"):
+		return map[string]interface{}{"type": "user", "user_id": inner[1:]}
+	case strings.HasPrefix(inner, "#"):
+		return map[string]interface{}{"type": "channel", "channel_id": inner[1:]}
+	case strings.HasPrefix(inner, "!subteam^"):
+		return map[string]interface{}{"type": "usergroup", "usergroup_id": strings.TrimPrefix(inner, "!subteam^")}
+	case inner == "!here" || inner == "!channel" || inner == "!everyone":
+		return map[string]interface{}{"type": "broadcast", "range": inner[1:]}
+	default:
+		return map[string]interface{}{"type": "text", "text": tok}
+	}
+}
+
+// spansToPlain drops all formatting, for plain_text surfaces (headers,
+// table cells).
+func spansToPlain(spans []mdSpan) string {
+	var b strings.Builder
+	for _, sp := range spans {
+		b.WriteString(sp.text)
+	}
+	return b.String()
+}
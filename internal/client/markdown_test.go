@@ -0,0 +1,196 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mdBlocks converts markdown and decodes the result into generic maps so
+// tests can assert on the exact JSON Slack would receive.
+func mdBlocks(t *testing.T, md string) []map[string]interface{} {
+	t.Helper()
+	data, err := json.Marshal(MarkdownToBlocks(md))
+	require.NoError(t, err)
+	var out []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &out))
+	return out
+}
+
+// mdRendered round-trips converted blocks through RenderBlocks.
+func mdRendered(t *testing.T, md string) string {
+	t.Helper()
+	data, err := json.Marshal(MarkdownToBlocks(md))
+	require.NoError(t, err)
+	return RenderBlocks(mustBlocks(t, string(data)), nil)
+}
+
+func TestMarkdownToBlocks_Empty(t *testing.T) {
+	assert.Empty(t, MarkdownToBlocks(""))
+	assert.Empty(t, MarkdownToBlocks("\n\n  \n"))
+}
+
+func TestMarkdownToBlocks_Headings(t *testing.T) {
+	blocks := mdBlocks(t, "# Deploy **report**\n## Summary ##\n### Details\nAll good.")
+	require.Len(t, blocks, 3)
+	assert.Equal(t, "header", blocks[0]["type"])
+	assert.Equal(t, "Deploy report", blocks[0]["text"].(map[string]interface{})["text"])
+	assert.Equal(t, "header", blocks[1]["type"])
+	assert.Equal(t, "Summary", blocks[1]["text"].(map[string]interface{})["text"])
+	assert.Equal(t, "section", blocks[2]["type"])
+	assert.Equal(t, "*Details*\nAll good.", blocks[2]["text"].(map[string]interface{})["text"])
+}
+
+func TestMarkdownToBlocks_SetextHeading(t *testing.T) {
+	blocks := mdBlocks(t, "Title\n=====\n\nSub\n---")
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "header", blocks[0]["type"])
+	assert.Equal(t, "header", blocks[1]["type"])
+}
+
+func TestMarkdownToBlocks_LongHeaderFallsBackToSection(t *testing.T) {
+	blocks := mdBlocks(t, "# "+strings.Repeat("x", maxHeaderTextLen+1))
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "section", blocks[0]["type"])
+}
+
+func TestMarkdownToBlocks_InlineFormatting(t *testing.T) {
+	tests := []struct {
+		name, md, want string
+	}{
+		{"bold", "**bold**", "*bold*"},
+		{"bold underscore", "__bold__", "*bold*"},
+		{"italic", "*it* and _it_", "_it_ and _it_"},
+		{"bold italic", "***both***", "*_both_*"},
+		{"strike", "~~gone~~", "~gone~"},
+		{"code", "run `make *all*`", "run `make *all*`"},
+		{"link", "[docs](https://example.com)", "<https://example.com|docs>"},
+		{"bold link", "**see [docs](https://e.com) now**", "*see <https://e.com|docs> now*"},
+		{"autolink", "<https://example.com>", "<https://example.com>"},
+		{"escapes", "a < b & c > d", "a &lt; b &amp; c &gt; d"},
+		{"backslash escape", `\*not italic\*`, "*not italic*"},
+		{"snake case", "use snake_case_name", "use snake_case_name"},
+		{"lone star", "5 * 3", "5 * 3"},
+		{"slack tokens", "hi <@U123> in <#C456|ops> <!here>", "hi <@U123> in <#C456|ops> <!here>"},
+		{"soft break", "one\ntwo", "one two"},
+		{"hard break", "one  \ntwo", "one\ntwo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocks := mdBlocks(t, tt.md)
+			require.Len(t, blocks, 1)
+			assert.Equal(t, "section", blocks[0]["type"])
+			assert.Equal(t, tt.want, blocks[0]["text"].(map[string]interface{})["text"])
+		})
+	}
+}
+
+func TestMarkdownToBlocks_ParagraphsMergeIntoOneSection(t *testing.T) {
+	blocks := mdBlocks(t, "first\n\nsecond\n\nthird")
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "first\n\nsecond\n\nthird", blocks[0]["text"].(map[string]interface{})["text"])
+}
+
+func TestMarkdownToBlocks_SectionLimit(t *testing.T) {
+	para := strings.Repeat("word ", 500) // 2500 chars
+	blocks := mdBlocks(t, para+"\n\n"+para+"\n\n"+strings.Repeat("y", 7000))
+	for i, b := range blocks {
+		text := b["text"].(map[string]interface{})["text"].(string)
+		assert.LessOrEqual(t, len(text), maxSectionTextLen, "block %d", i)
+	}
+	assert.Len(t, blocks, 5) // two paragraphs, then 7000 chars split in three
+}
+
+func TestMarkdownToBlocks_Lists(t *testing.T) {
+	md := "- one\n- **two**\n  - nested\n- three\n\n1. first\n2. second"
+	blocks := mdBlocks(t, md)
+	// Adjacent lists share one rich_text block, one rich_text_list per
+	// run of identical depth and style.
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "rich_text", blocks[0]["type"])
+	lists := blocks[0]["elements"].([]interface{})
+	require.Len(t, lists, 4)
+	assert.Equal(t, "ordered", lists[3].(map[string]interface{})["style"])
+	assert.Equal(t, float64(0), lists[0].(map[string]interface{})["indent"])
+	assert.Equal(t, float64(1), lists[1].(map[string]interface{})["indent"])
+	assert.Equal(t, float64(0), lists[2].(map[string]interface{})["indent"])
+
+	assert.Equal(t, "- one\n- **two**\n  - nested\n- three\n1. first\n2. second", mdRendered(t, md))
+}
+
+func TestMarkdownToBlocks_OrderedListContinuesAfterNesting(t *testing.T) {
+	got := mdRendered(t, "1. a\n2. b\n   - x\n3. c")
+	assert.Equal(t, "1. a\n2. b\n  - x\n3. c", got)
+}
+
+func TestMarkdownToBlocks_OrderedListStartNumber(t *testing.T) {
+	assert.Equal(t, "4. d\n5. e", mdRendered(t, "4. d\n5. e"))
+}
+
+func TestMarkdownToBlocks_ListContinuationLine(t *testing.T) {
+	assert.Equal(t, "- wrapped item text", mdRendered(t, "- wrapped item\n  text"))
+}
+
+func TestMarkdownToBlocks_FencedCode(t *testing.T) {
+	blocks := mdBlocks(t, "```go\nfunc main() {\n\t**x**\n}\n```")
+	require.Len(t, blocks, 1)
+	el := blocks[0]["elements"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "rich_text_preformatted", el["type"])
+	text := el["elements"].([]interface{})[0].(map[string]interface{})["text"]
+	assert.Equal(t, "func main() {\n\t**x**\n}", text)
+}
+
+func TestMarkdownToBlocks_LongCodeIsSplit(t *testing.T) {
+	line := strings.Repeat("x", 99)
+	code := strings.TrimSuffix(strings.Repeat(line+"\n", 70), "\n")
+	blocks := mdBlocks(t, "```\n"+code+"\n```")
+	assert.Len(t, blocks, 3)
+}
+
+func TestMarkdownToBlocks_Table(t *testing.T) {
+	md := "| Service | Status |\n|---|:---:|\n| api | **ok** |\n| worker-long | degraded |"
+	got := mdRendered(t, md)
+	assert.Equal(t, "```\nService     | Status\n------------+---------\napi         | ok\nworker-long | degraded\n```", got)
+}
+
+func TestMarkdownToBlocks_QuoteDividerContextImage(t *testing.T) {
+	md := "> quoted *text*\n\n---\n\n<sub>generated by ci</sub>\n\n![chart](https://e.com/c.png)"
+	blocks := mdBlocks(t, md)
+	require.Len(t, blocks, 4)
+	assert.Equal(t, "rich_text", blocks[0]["type"])
+	assert.Equal(t, "rich_text_quote", blocks[0]["elements"].([]interface{})[0].(map[string]interface{})["type"])
+	assert.Equal(t, "divider", blocks[1]["type"])
+	assert.Equal(t, "context", blocks[2]["type"])
+	assert.Equal(t, "image", blocks[3]["type"])
+	assert.Equal(t, "https://e.com/c.png", blocks[3]["image_url"])
+	assert.Equal(t, "chart", blocks[3]["alt_text"])
+}
+
+func TestMarkdownToBlocks_RichTextSlackTokens(t *testing.T) {
+	blocks := mdBlocks(t, "- ping <@U123> and <!here>")
+	list := blocks[0]["elements"].([]interface{})[0].(map[string]interface{})
+	section := list["elements"].([]interface{})[0].(map[string]interface{})
+	els := section["elements"].([]interface{})
+	require.Len(t, els, 4)
+	assert.Equal(t, "user", els[1].(map[string]interface{})["type"])
+	assert.Equal(t, "U123", els[1].(map[string]interface{})["user_id"])
+	assert.Equal(t, "broadcast", els[3].(map[string]interface{})["type"])
+}
+
+func TestMarkdownToBlocks_DocumentOrder(t *testing.T) {
+	md := "# Report\n\nIntro.\n\n- a\n\nOutro."
+	blocks := mdBlocks(t, md)
+	var types []string
+	for _, b := range blocks {
+		types = append(types, b["type"].(string))
+	}
+	assert.Equal(t, []string{"header", "section", "rich_text", "section"}, types)
+}
+
+func TestCodeBlock(t *testing.T) {
+	assert.Equal(t, "```\nif a &lt; b &amp;&amp; c {}\n```", CodeBlock("if a < b && c {}"))
+	assert.Equal(t, "```\necho '''\n```", CodeBlock("echo ```"), "a fence in the text cannot close the block")
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimitError is returned for a call Slack rejected as rate limited.
+// RetryAfter is how long Slack asked callers to wait, zero when it did not
+// say.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return "slack API error: ratelimited"
+}
+
+// RetryBackoff is the wait after a rate-limited call when Slack sends no
+// Retry-After; the n-th retry waits n times this long. A variable so tests
+// can shorten it.
+var RetryBackoff = 2 * time.Second
+
+// WithRetry calls fn until it succeeds, fails with anything but a rate
+// limit, or has been tried attempts times. Before every attempt it waits on
+// limiter (nil = no pacing), so retries count against the caller's rate too.
+// After a rate limit it waits as long as Slack's Retry-After asks, or a
+// growing backoff when there is none.
+func WithRetry(attempts int, limiter <-chan time.Time, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		if limiter != nil {
+			<-limiter
+		}
+		err := fn()
+		if err == nil || !IsSlackError(err, "ratelimited") || attempt >= attempts {
+			return err
+		}
+		wait := time.Duration(attempt) * RetryBackoff
+		var rl *RateLimitError
+		if errors.As(err, &rl) && rl.RetryAfter > 0 {
+			wait = rl.RetryAfter
+		}
+		time.Sleep(wait)
+	}
+}
+
+// NewRateLimiter returns a ticker firing rate times a second, for pacing
+// calls with WithRetry. rate must be positive; one too large for a
+// nanosecond interval fires as fast as a ticker can.
+func NewRateLimiter(rate float64) *time.Ticker {
+	return time.NewTicker(max(time.Duration(float64(time.Second)/rate), time.Nanosecond))
+}
+
+// checkResponse returns the error a Slack API response carries, if any.
+// Rate limits (HTTP 429 or a "ratelimited" error) become a *RateLimitError
+// holding the Retry-After header.
+func checkResponse(resp *http.Response, body []byte) error {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{RetryAfter: retryAfter(resp.Header)}
+	}
+
+	var slackResp SlackResponse
+	if err := json.Unmarshal(body, &slackResp); err != nil {
+		return err
+	}
+	if slackResp.OK {
+		return nil
+	}
+	if slackResp.Error == "ratelimited" {
+		return &RateLimitError{RetryAfter: retryAfter(resp.Header)}
+	}
+	return fmt.Errorf("slack API error: %s", slackResp.Error)
+}
+
+// retryAfter reads the Retry-After header, which Slack sends in seconds.
+func retryAfter(h http.Header) time.Duration {
+	secs, err := strconv.Atoi(h.Get("Retry-After"))
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
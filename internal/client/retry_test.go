@@ -0,0 +1,81 @@
+package client
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet_RateLimitedCarriesRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "7")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := NewWithConfig(server.URL, "test-token", nil)
+	_, err := c.GetChannelInfo("C123")
+
+	var rl *RateLimitError
+	require.True(t, errors.As(err, &rl), "got %v", err)
+	assert.Equal(t, 7*time.Second, rl.RetryAfter)
+	assert.True(t, IsSlackError(err, "ratelimited"))
+}
+
+func TestWithRetry_HonoursRetryAfter(t *testing.T) {
+	defer func(d time.Duration) { RetryBackoff = d }(RetryBackoff)
+	RetryBackoff = time.Hour // would hang if Retry-After were ignored
+
+	calls := 0
+	start := time.Now()
+	err := WithRetry(3, nil, func() error {
+		calls++
+		if calls == 1 {
+			return &RateLimitError{RetryAfter: time.Millisecond}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Less(t, time.Since(start), time.Minute)
+}
+
+func TestWithRetry_StopsOnOtherErrorsAndAfterAttempts(t *testing.T) {
+	defer func(d time.Duration) { RetryBackoff = d }(RetryBackoff)
+	RetryBackoff = time.Millisecond
+
+	calls := 0
+	err := WithRetry(3, nil, func() error {
+		calls++
+		return errors.New("slack API error: channel_not_found")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	limiter := make(chan time.Time, 3)
+	for i := 0; i < 3; i++ {
+		limiter <- time.Now()
+	}
+	err = WithRetry(3, limiter, func() error {
+		calls++
+		return &RateLimitError{}
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Empty(t, limiter, "every attempt waits on the limiter")
+}
+
+func TestNewRateLimiter_HugeRate(t *testing.T) {
+	for _, rate := range []float64{2e9, 1e300, math.Inf(1)} {
+		limiter := NewRateLimiter(rate)
+		<-limiter.C
+		limiter.Stop()
+	}
+}
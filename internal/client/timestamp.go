@@ -0,0 +1,37 @@
+package client
+
+import (
+	"cmp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CompareTS compares two Slack timestamps ("seconds.micros") numerically,
+// returning -1, 0 or 1. The empty string sorts before every timestamp.
+func CompareTS(a, b string) int {
+	as, af := splitTS(a)
+	bs, bf := splitTS(b)
+	if c := cmp.Compare(as, bs); c != 0 {
+		return c
+	}
+	return cmp.Compare(af, bf)
+}
+
+// SortByTS orders messages oldest first.
+func SortByTS(msgs []Message) {
+	sort.SliceStable(msgs, func(i, j int) bool {
+		return CompareTS(msgs[i].TS, msgs[j].TS) < 0
+	})
+}
+
+func splitTS(ts string) (sec, frac int64) {
+	if ts == "" {
+		return -1, 0
+	}
+	s, f, _ := strings.Cut(ts, ".")
+	sec, _ = strconv.ParseInt(s, 10, 64)
+	f = (f + "000000")[:6]
+	frac, _ = strconv.ParseInt(f, 10, 64)
+	return sec, frac
+}
@@ -0,0 +1,18 @@
+package blocks
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the blocks command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "blocks",
+		Short: "Work with Block Kit payloads offline",
+	}
+
+	cmd.AddCommand(newValidateCmd())
+	cmd.AddCommand(newPreviewCmd())
+
+	return cmd
+}
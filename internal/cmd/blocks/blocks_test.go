@@ -0,0 +1,139 @@
+package blocks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func captureOutput(fn func()) string {
+	var buf bytes.Buffer
+	output.Writer = &buf
+	defer func() { output.Writer = os.Stdout }()
+	fn()
+	return buf.String()
+}
+
+func TestRunValidate_ValidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"blocks":[{"type":"divider"},{"type":"section","text":{"type":"mrkdwn","text":"hi"}}]}`), 0o600))
+
+	out := captureOutput(func() {
+		require.NoError(t, runValidate(&validateOptions{file: path}))
+	})
+	assert.Equal(t, "Blocks are valid (2 blocks)\n", out)
+}
+
+func TestRunValidate_Stdin(t *testing.T) {
+	opts := &validateOptions{file: "-", stdin: strings.NewReader(`[{"type":"divider"}]`)}
+	out := captureOutput(func() {
+		require.NoError(t, runValidate(opts))
+	})
+	assert.Contains(t, out, "valid (1 blocks)")
+}
+
+func TestRunValidate_ReportsPaths(t *testing.T) {
+	long := strings.Repeat("x", 3001)
+	opts := &validateOptions{blocksJSON: `[{"type":"divider"},{"type":"section","text":{"type":"mrkdwn","text":"` + long + `"}},{"type":"bogus"}]`}
+	err := runValidate(opts)
+	require.Error(t, err)
+	assert.Equal(t, "blocks[1].text.text: exceeds 3000 chars\nblocks[2].type: unknown block type \"bogus\"", err.Error())
+}
+
+func TestRunValidate_InvalidJSON(t *testing.T) {
+	err := runValidate(&validateOptions{blocksJSON: "not json"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid blocks JSON")
+}
+
+func TestRunValidate_Sources(t *testing.T) {
+	err := runValidate(&validateOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--file or --blocks")
+
+	err = runValidate(&validateOptions{file: "a.json", blocksJSON: "[]"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only one of")
+
+	err = runValidate(&validateOptions{file: "/nonexistent/blocks.json"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading blocks file")
+}
+
+func TestRunPreview_Offline(t *testing.T) {
+	payload := `[
+		{"type":"header","text":{"type":"plain_text","text":"Deploy report"}},
+		{"type":"section","text":{"type":"mrkdwn","text":"All *green* for <#C123|ops>"}},
+		{"type":"rich_text","elements":[{"type":"rich_text_section","elements":[
+			{"type":"text","text":"owner "},{"type":"user","user_id":"U123"}]}]}
+	]`
+	out := captureOutput(func() {
+		require.NoError(t, runPreview(&previewOptions{blocksJSON: payload}, nil))
+	})
+	assert.Contains(t, out, "Deploy report")
+	assert.Contains(t, out, "All *green* for <#C123|ops>")
+	assert.Contains(t, out, "owner @U123")
+}
+
+func TestRunPreview_MessageObject(t *testing.T) {
+	opts := &previewOptions{
+		file:  "-",
+		stdin: strings.NewReader(`{"text":"fallback","attachments":[{"title":"Build","text":"passed"}]}`),
+	}
+	out := captureOutput(func() {
+		require.NoError(t, runPreview(opts, nil))
+	})
+	assert.Contains(t, out, "fallback")
+	assert.Contains(t, out, "passed")
+}
+
+func TestRunPreview_Empty(t *testing.T) {
+	out := captureOutput(func() {
+		require.NoError(t, runPreview(&previewOptions{blocksJSON: `[{"type":"divider"}]`}, nil))
+	})
+	assert.Equal(t, "(nothing to display)\n", out)
+}
+
+func TestRunPreview_InvalidJSON(t *testing.T) {
+	err := runPreview(&previewOptions{blocksJSON: `[{`}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid blocks JSON")
+}
+
+func TestRunPreview_Resolve(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": "U123", "name": "alice", "profile": map[string]interface{}{"display_name": "Alice"}},
+			})
+		case "/conversations.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"channel": map[string]interface{}{"id": "C123", "name": "ops-alerts"},
+			})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	payload := `[{"type":"section","text":{"type":"mrkdwn","text":"ping <@U123> in <#C123|ops>"}}]`
+	out := captureOutput(func() {
+		require.NoError(t, runPreview(&previewOptions{blocksJSON: payload, resolve: true}, c))
+	})
+	assert.Equal(t, "ping @Alice in #ops-alerts\n", out)
+}
@@ -0,0 +1,144 @@
+package blocks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type previewOptions struct {
+	file       string
+	blocksJSON string
+	resolve    bool
+	stdin      io.Reader // For testing
+}
+
+func newPreviewCmd() *cobra.Command {
+	opts := &previewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Render a Block Kit payload as slck would display it",
+		Long: `Render a Block Kit payload through the same renderer that
+"slck messages history" and "thread" use, without posting anything.
+
+The payload may be a JSON array of blocks, or a message object with
+"blocks" and optionally "text" and "attachments" (a chat.postMessage body
+or a Block Kit Builder export). Validation problems are printed to stderr
+as warnings; the preview is still rendered.
+
+Rendering is fully offline by default: user and channel mentions are shown
+as IDs. Pass --resolve to look up their names (requires a token).
+
+Examples:
+  slck blocks preview --file report.json
+  generate-report | slck blocks preview --file -
+  slck blocks preview --file report.json --resolve`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPreview(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.file, "file", "", "Read the payload from a JSON file (- for stdin)")
+	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Inline Block Kit JSON")
+	cmd.Flags().BoolVar(&opts.resolve, "resolve", false, "Resolve user and channel mentions to names (calls the Slack API)")
+
+	return cmd
+}
+
+// previewPayload is the subset of a chat.postMessage body the renderer
+// reads.
+type previewPayload struct {
+	Text        string              `json:"text"`
+	Blocks      []client.Block      `json:"blocks"`
+	Attachments []client.Attachment `json:"attachments"`
+}
+
+func runPreview(opts *previewOptions, c *client.Client) error {
+	data, err := readPayload(opts.file, opts.blocksJSON, opts.stdin)
+	if err != nil {
+		return err
+	}
+
+	var payload previewPayload
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		err = json.Unmarshal(data, &payload)
+	} else {
+		err = json.Unmarshal(data, &payload.Blocks)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid blocks JSON: %w", err)
+	}
+
+	if len(payload.Blocks) > 0 {
+		if raw, err := client.ParseBlocks(data); err == nil {
+			if verr := client.ValidateBlocks(raw); verr != nil {
+				for _, line := range strings.Split(verr.Error(), "\n") {
+					fmt.Fprintf(os.Stderr, "warning: %s\n", line)
+				}
+			}
+		}
+	}
+
+	var resolver *client.UserResolver
+	if opts.resolve {
+		if c == nil {
+			c, err = client.New()
+			if err != nil {
+				return err
+			}
+		}
+		resolver = client.NewUserResolver(c)
+	}
+
+	rendered := client.RenderMessage(client.MessageContent{
+		Text:        payload.Text,
+		Blocks:      payload.Blocks,
+		Attachments: payload.Attachments,
+	}, resolver)
+
+	body := rendered.Body
+	if opts.resolve {
+		body = resolveChannelMentions(body, c)
+	}
+	if strings.TrimSpace(body) == "" {
+		output.Println("(nothing to display)")
+		return nil
+	}
+	output.Println(body)
+	return nil
+}
+
+var channelMentionRegex = regexp.MustCompile(`<#([CG][A-Z0-9]+)(?:\|([^>]*))?>`)
+
+// resolveChannelMentions rewrites <#C…> and <#C…|name> mentions as #name,
+// looking up each channel once. Lookups that fail fall back to the label
+// Slack embedded, then to the raw ID.
+func resolveChannelMentions(text string, c *client.Client) string {
+	names := map[string]string{}
+	return channelMentionRegex.ReplaceAllStringFunc(text, func(match string) string {
+		sub := channelMentionRegex.FindStringSubmatch(match)
+		id, label := sub[1], sub[2]
+		name, ok := names[id]
+		if !ok {
+			if ch, err := c.GetChannelInfo(id); err == nil && ch.Name != "" {
+				name = ch.Name
+			} else if label != "" {
+				name = label
+			} else {
+				name = id
+			}
+			names[id] = name
+		}
+		return "#" + name
+	})
+}
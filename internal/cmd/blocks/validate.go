@@ -0,0 +1,95 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type validateOptions struct {
+	file       string
+	blocksJSON string
+	stdin      io.Reader // For testing
+}
+
+func newValidateCmd() *cobra.Command {
+	opts := &validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Check a Block Kit payload without sending it",
+		Long: `Check a Block Kit payload against Slack's message rules without calling
+the API: known block types, required fields, the 50-block and per-field
+text limits, and duplicate block_id/action_id values.
+
+The payload may be a JSON array of blocks or an object with a "blocks"
+array, as exported by Block Kit Builder. Every problem is reported with a
+JSON path, e.g. "blocks[3].text.text: exceeds 3000 chars", and the command
+exits non-zero if any are found.
+
+Examples:
+  slck blocks validate --file report.json
+  generate-report | slck blocks validate --file -
+  slck blocks validate --blocks '[{"type":"divider"}]'`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runValidate(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.file, "file", "", "Read blocks from a JSON file (- for stdin)")
+	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Inline Block Kit JSON")
+
+	return cmd
+}
+
+func runValidate(opts *validateOptions) error {
+	data, err := readPayload(opts.file, opts.blocksJSON, opts.stdin)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := client.ParseBlocks(data)
+	if err != nil {
+		return fmt.Errorf("invalid blocks JSON: %w", err)
+	}
+	if err := client.ValidateBlocks(blocks); err != nil {
+		return err
+	}
+
+	output.Printf("Blocks are valid (%d blocks)\n", len(blocks))
+	return nil
+}
+
+// readPayload returns the Block Kit JSON from exactly one of --file (where
+// "-" reads stdin) or --blocks.
+func readPayload(file, inline string, stdin io.Reader) ([]byte, error) {
+	switch {
+	case file != "" && inline != "":
+		return nil, fmt.Errorf("only one of --file or --blocks can be specified")
+	case inline != "":
+		return []byte(inline), nil
+	case file == "-":
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("reading blocks from stdin: %w", err)
+		}
+		return data, nil
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading blocks file: %w", err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("provide blocks via --file or --blocks")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -77,6 +78,7 @@ func runArchive(channel string, opts *archiveOptions, c *client.Client) error {
 		}
 		return client.WrapError(fmt.Sprintf("archive channel %s", channel), err)
 	}
+	c.Record(journal.Entry{Action: journal.ActionArchive, Channel: channelID})
 
 	output.Printf("Archived channel: %s\n", channel)
 	return nil
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -48,6 +49,7 @@ func runInvite(channel string, userIDs []string, opts *inviteOptions, c *client.
 		}
 		return client.WrapError(fmt.Sprintf("invite to channel %s", channel), err)
 	}
+	c.Record(journal.Entry{Action: journal.ActionInvite, Channel: channelID, Users: userIDs})
 
 	output.Printf("Invited %d user(s) to channel %s\n", len(userIDs), channel)
 	return nil
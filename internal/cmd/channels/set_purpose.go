@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -37,9 +38,12 @@ func runSetPurpose(channel, purpose string, opts *setPurposeOptions, c *client.C
 		return err
 	}
 
+	entry := c.ChannelEntry(journal.ActionPurpose, channelID)
 	if err := c.SetChannelPurpose(channelID, purpose); err != nil {
 		return err
 	}
+	entry.Text = purpose
+	c.Record(entry)
 
 	output.Printf("Set purpose for channel %s\n", channel)
 	return nil
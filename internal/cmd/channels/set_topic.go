@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -37,9 +38,12 @@ func runSetTopic(channel, topic string, opts *setTopicOptions, c *client.Client)
 		return err
 	}
 
+	entry := c.ChannelEntry(journal.ActionTopic, channelID)
 	if err := c.SetChannelTopic(channelID, topic); err != nil {
 		return err
 	}
+	entry.Text = topic
+	c.Record(entry)
 
 	output.Printf("Set topic for channel %s\n", channel)
 	return nil
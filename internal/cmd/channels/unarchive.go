@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -50,6 +51,7 @@ func runUnarchive(channel string, opts *unarchiveOptions, c *client.Client) erro
 		}
 		return err
 	}
+	c.Record(journal.Entry{Action: journal.ActionUnarchive, Channel: channelID})
 
 	output.Printf("Unarchived channel: %s\n", channel)
 	return nil
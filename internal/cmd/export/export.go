@@ -0,0 +1,252 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/config"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// maxExportAttempts bounds retries of a read that Slack rate-limited.
+const maxExportAttempts = 5
+
+// exportReadInterval spaces history and thread reads so a channel with many
+// threads stays under Slack's rate limits. A variable for tests.
+var exportReadInterval = 500 * time.Millisecond
+
+type exportOptions struct {
+	dir   string
+	files bool
+	full  bool
+}
+
+// NewCmd creates the export command
+func NewCmd() *cobra.Command {
+	opts := &exportOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "export <channel>...",
+		Short: "Export channel history in Slack's export format",
+		Long: `Export the history of one or more channels to a directory using Slack's
+standard export layout:
+
+  <dir>/channels.json          the exported conversations
+  <dir>/users.json             every user in the workspace
+  <dir>/<channel>/YYYY-MM-DD.json   that day's messages (UTC), oldest first
+
+Thread replies are written to the day they were posted, next to their parent.
+With --files, attached files are downloaded to <dir>/__uploads/<file-id>/.
+
+Exports are resumable: progress is recorded in <dir>/.slck-export.json and a
+re-run only fetches messages newer than the last export, merging them into
+the existing day files. Replies added later to threads exported by an
+earlier run are only picked up with --full, which re-reads the whole history.
+
+Examples:
+  slck export general incidents --dir out/
+  slck export C0123456789 --dir out/ --files
+  slck export general --dir out/ --full`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(args, opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.dir, "dir", "", "Directory to write the export to (required)")
+	cmd.Flags().BoolVar(&opts.files, "files", false, "Download attached files")
+	cmd.Flags().BoolVar(&opts.full, "full", false, "Re-read the whole history instead of resuming")
+	_ = cmd.MarkFlagRequired("dir")
+
+	return cmd
+}
+
+func runExport(channels []string, opts *exportOptions, c *client.Client) error {
+	if opts.dir == "" {
+		return fmt.Errorf("--dir is required")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Resolve everything up front so a typo fails before any writes.
+	ids := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		id, err := c.ResolveMessageDestination(ch)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := os.MkdirAll(opts.dir, config.DirPerm); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	state, err := loadState(opts.dir)
+	if err != nil {
+		return err
+	}
+	var infos []client.Channel
+	if err := readJSON(filepath.Join(opts.dir, "channels.json"), &infos); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		info, err := c.GetChannelInfo(id)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get channel %s", id), err)
+		}
+
+		prev := state.Channels[id]
+		if prev.Dir == "" {
+			prev.Dir = channelDir(info)
+		}
+		oldest := prev.Latest
+		if opts.full {
+			oldest = ""
+		}
+
+		res, err := exportChannel(c, opts, id, prev.Dir, oldest)
+		if err != nil {
+			return err
+		}
+		if client.CompareTS(res.latest, prev.Latest) > 0 {
+			prev.Latest = res.latest
+		}
+		state.Channels[id] = prev
+		infos = upsertChannel(infos, *info)
+
+		// Persist progress per channel so an interrupted run resumes here.
+		if err := writeJSON(filepath.Join(opts.dir, "channels.json"), infos); err != nil {
+			return err
+		}
+		if err := saveState(opts.dir, state); err != nil {
+			return err
+		}
+
+		name := info.Name
+		if name == "" {
+			name = id
+		}
+		output.Printf("%s: %d new messages, %d replies", name, res.messages, res.replies)
+		if opts.files {
+			output.Printf(", %d files", res.files)
+		}
+		output.Printf(" -> %s\n", filepath.Join(opts.dir, prev.Dir))
+	}
+
+	users, err := c.ListAllUsers()
+	if err != nil {
+		return client.WrapError("list users", err)
+	}
+	if err := writeJSON(filepath.Join(opts.dir, "users.json"), users); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type channelResult struct {
+	messages int
+	replies  int
+	files    int
+	latest   string
+}
+
+// exportChannel fetches messages after oldest (the whole history when
+// empty) with their threads, merges them into the channel's day files and
+// optionally downloads their files.
+func exportChannel(c *client.Client, opts *exportOptions, channelID, dir, oldest string) (*channelResult, error) {
+	limiter := time.NewTicker(exportReadInterval)
+	defer limiter.Stop()
+
+	var msgs []client.Message
+	err := client.WithRetry(maxExportAttempts, limiter.C, func() error {
+		var err error
+		msgs, err = c.GetChannelHistory(channelID, 0, oldest, "")
+		return err
+	})
+	if err != nil {
+		return nil, client.WrapError(fmt.Sprintf("get history for %s", channelID), err)
+	}
+
+	res := &channelResult{}
+	var all []client.Message
+	for _, m := range msgs {
+		if client.CompareTS(m.TS, oldest) <= 0 {
+			continue
+		}
+		all = append(all, m)
+		res.messages++
+		if client.CompareTS(m.TS, res.latest) > 0 {
+			res.latest = m.TS
+		}
+		if m.ReplyCount == 0 {
+			continue
+		}
+		var replies []client.Message
+		err := client.WithRetry(maxExportAttempts, limiter.C, func() error {
+			var err error
+			replies, err = c.GetThreadReplies(channelID, m.TS, 0, "")
+			return err
+		})
+		if err != nil {
+			return nil, client.WrapError(fmt.Sprintf("get replies for %s", m.TS), err)
+		}
+		for _, r := range replies {
+			if r.TS != m.TS {
+				all = append(all, r)
+				res.replies++
+			}
+		}
+	}
+
+	if err := writeDays(filepath.Join(opts.dir, dir), all); err != nil {
+		return nil, err
+	}
+
+	if opts.files {
+		for _, m := range all {
+			for _, f := range m.Files {
+				downloaded, err := downloadFile(c, opts.dir, f)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "warning: could not download file %s: %v\n", f.ID, err)
+					continue
+				}
+				if downloaded {
+					res.files++
+				}
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// upsertChannel replaces the entry for ch (matched by ID) or appends it,
+// keeping the list ordered by name.
+func upsertChannel(infos []client.Channel, ch client.Channel) []client.Channel {
+	replaced := false
+	for i := range infos {
+		if infos[i].ID == ch.ID {
+			infos[i] = ch
+			replaced = true
+		}
+	}
+	if !replaced {
+		infos = append(infos, ch)
+	}
+	sort.SliceStable(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
@@ -0,0 +1,229 @@
+package export
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/config"
+)
+
+// exportServer fakes the Slack endpoints used by export. history holds the
+// channel's top-level messages; replies maps a parent ts to its thread.
+type exportServer struct {
+	history   []map[string]interface{}
+	replies   map[string][]map[string]interface{}
+	oldest    []string
+	downloads int
+	// rateLimited is how many thread reads are refused with a 429 first
+	rateLimited int
+	fileServer  *httptest.Server
+}
+
+func (s *exportServer) start(t *testing.T) *client.Client {
+	t.Helper()
+	s.fileServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.downloads++
+		_, _ = w.Write([]byte("file body"))
+	}))
+	t.Cleanup(s.fileServer.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/conversations.info":
+			resp["channel"] = map[string]interface{}{"id": "C0000000001", "name": "general"}
+		case "/conversations.history":
+			oldest := r.URL.Query().Get("oldest")
+			s.oldest = append(s.oldest, oldest)
+			var msgs []map[string]interface{}
+			for _, m := range s.history {
+				if client.CompareTS(m["ts"].(string), oldest) > 0 {
+					msgs = append(msgs, m)
+				}
+			}
+			resp["messages"] = msgs
+		case "/conversations.replies":
+			if s.rateLimited > 0 {
+				s.rateLimited--
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			resp["messages"] = s.replies[r.URL.Query().Get("ts")]
+		case "/users.list":
+			resp["members"] = []map[string]interface{}{{"id": "U1", "name": "alice"}}
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func TestMain(m *testing.M) {
+	exportReadInterval = time.Millisecond
+	client.RetryBackoff = time.Millisecond
+	os.Exit(m.Run())
+}
+
+func readDay(t *testing.T, path string) []map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var msgs []map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &msgs))
+	return msgs
+}
+
+func tsList(msgs []map[string]interface{}) []string {
+	var out []string
+	for _, m := range msgs {
+		out = append(out, m["ts"].(string))
+	}
+	return out
+}
+
+func TestRunExport_WritesSlackLayout(t *testing.T) {
+	// 1700000000 is 2023-11-14 22:13:20 UTC; +7200 falls on the 15th.
+	s := &exportServer{
+		history: []map[string]interface{}{
+			{"type": "message", "user": "U1", "text": "later", "ts": "1700007200.000100"},
+			{"type": "message", "subtype": "bot_message", "text": "parent", "ts": "1700000000.000100",
+				"reply_count": 1, "latest_reply": "1700007300.000100", "pinned_to": []string{"C0000000001"}},
+		},
+		replies: map[string][]map[string]interface{}{
+			"1700000000.000100": {
+				{"type": "message", "text": "parent", "ts": "1700000000.000100"},
+				{"type": "message", "user": "U1", "text": "reply", "ts": "1700007300.000100", "thread_ts": "1700000000.000100"},
+			},
+		},
+	}
+	c := s.start(t)
+	dir := t.TempDir()
+
+	err := runExport([]string{"C0000000001"}, &exportOptions{dir: dir}, c)
+	require.NoError(t, err)
+
+	day1 := readDay(t, filepath.Join(dir, "general", "2023-11-14.json"))
+	assert.Equal(t, []string{"1700000000.000100"}, tsList(day1))
+	assert.Equal(t, "bot_message", day1[0]["subtype"])
+	assert.Equal(t, []interface{}{"C0000000001"}, day1[0]["pinned_to"], "unmodeled fields are kept")
+
+	day2 := readDay(t, filepath.Join(dir, "general", "2023-11-15.json"))
+	assert.Equal(t, []string{"1700007200.000100", "1700007300.000100"}, tsList(day2))
+
+	var channels []map[string]interface{}
+	data, err := os.ReadFile(filepath.Join(dir, "channels.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &channels))
+	require.Len(t, channels, 1)
+	assert.Equal(t, "general", channels[0]["name"])
+
+	assert.FileExists(t, filepath.Join(dir, "users.json"))
+}
+
+func TestRunExport_RetriesRateLimitedReads(t *testing.T) {
+	s := &exportServer{
+		history: []map[string]interface{}{
+			{"type": "message", "text": "parent", "ts": "1700000000.000100", "reply_count": 1},
+		},
+		replies: map[string][]map[string]interface{}{
+			"1700000000.000100": {
+				{"type": "message", "text": "parent", "ts": "1700000000.000100"},
+				{"type": "message", "text": "reply", "ts": "1700000001.000100", "thread_ts": "1700000000.000100"},
+			},
+		},
+		rateLimited: 2,
+	}
+	c := s.start(t)
+	dir := t.TempDir()
+
+	require.NoError(t, runExport([]string{"C0000000001"}, &exportOptions{dir: dir}, c))
+	day := readDay(t, filepath.Join(dir, "general", "2023-11-14.json"))
+	assert.Equal(t, []string{"1700000000.000100", "1700000001.000100"}, tsList(day))
+}
+
+func TestRunExport_ResumesFromLastMessage(t *testing.T) {
+	s := &exportServer{
+		history: []map[string]interface{}{
+			{"type": "message", "user": "U1", "text": "first", "ts": "1700000000.000100"},
+		},
+	}
+	c := s.start(t)
+	dir := t.TempDir()
+	opts := &exportOptions{dir: dir}
+
+	require.NoError(t, runExport([]string{"C0000000001"}, opts, c))
+
+	s.history = append(s.history, map[string]interface{}{
+		"type": "message", "user": "U1", "text": "second", "ts": "1700000100.000100",
+	})
+	require.NoError(t, runExport([]string{"C0000000001"}, opts, c))
+
+	assert.Equal(t, []string{"", "1700000000.000100"}, s.oldest)
+	day := readDay(t, filepath.Join(dir, "general", "2023-11-14.json"))
+	assert.Equal(t, []string{"1700000000.000100", "1700000100.000100"}, tsList(day))
+
+	// --full re-reads everything without duplicating what is on disk.
+	opts.full = true
+	require.NoError(t, runExport([]string{"C0000000001"}, opts, c))
+	assert.Equal(t, "", s.oldest[2])
+	day = readDay(t, filepath.Join(dir, "general", "2023-11-14.json"))
+	assert.Len(t, day, 2)
+}
+
+func TestRunExport_DownloadsFilesOnce(t *testing.T) {
+	s := &exportServer{}
+	c := s.start(t)
+	s.history = []map[string]interface{}{
+		{"type": "message", "user": "U1", "text": "", "ts": "1700000000.000100",
+			"files": []map[string]interface{}{{
+				"id": "F1", "name": "report.pdf", "url_private_download": s.fileServer.URL + "/report.pdf",
+			}}},
+	}
+	dir := t.TempDir()
+	opts := &exportOptions{dir: dir, files: true, full: true}
+
+	require.NoError(t, runExport([]string{"C0000000001"}, opts, c))
+	require.NoError(t, runExport([]string{"C0000000001"}, opts, c))
+
+	data, err := os.ReadFile(filepath.Join(dir, uploadsDir, "F1", "report.pdf"))
+	require.NoError(t, err)
+	assert.Equal(t, "file body", string(data))
+	assert.Equal(t, 1, s.downloads)
+}
+
+func TestRunExport_KeepsFilesPrivate(t *testing.T) {
+	s := &exportServer{}
+	c := s.start(t)
+	s.history = []map[string]interface{}{
+		{"type": "message", "user": "U1", "text": "hi", "ts": "1700000000.000100",
+			"files": []map[string]interface{}{{
+				"id": "F1", "name": "report.pdf", "url_private_download": s.fileServer.URL + "/report.pdf",
+			}}},
+	}
+	dir := filepath.Join(t.TempDir(), "export")
+
+	require.NoError(t, runExport([]string{"C0000000001"}, &exportOptions{dir: dir, files: true}, c))
+
+	for path, want := range map[string]os.FileMode{
+		dir:                           config.DirPerm,
+		filepath.Join(dir, "general"): config.DirPerm,
+		filepath.Join(dir, "general", "2023-11-14.json"):   config.FilePerm,
+		filepath.Join(dir, uploadsDir, "F1"):               config.DirPerm,
+		filepath.Join(dir, uploadsDir, "F1", "report.pdf"): config.FilePerm,
+	} {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, want, info.Mode().Perm(), path)
+	}
+}
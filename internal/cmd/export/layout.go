@@ -0,0 +1,206 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/config"
+)
+
+// stateFile records per-channel progress inside the export directory.
+const stateFile = ".slck-export.json"
+
+// uploadsDir holds downloaded files, one directory per file ID, the same
+// layout other Slack export tools use.
+const uploadsDir = "__uploads"
+
+type exportState struct {
+	Channels map[string]channelState `json:"channels"`
+}
+
+type channelState struct {
+	// Dir is the channel's directory, fixed on first export so a renamed
+	// channel keeps writing to the same place.
+	Dir string `json:"dir"`
+	// Latest is the newest top-level message exported.
+	Latest string `json:"latest"`
+}
+
+func loadState(dir string) (*exportState, error) {
+	state := &exportState{}
+	if err := readJSON(filepath.Join(dir, stateFile), state); err != nil {
+		return nil, err
+	}
+	if state.Channels == nil {
+		state.Channels = map[string]channelState{}
+	}
+	return state, nil
+}
+
+func saveState(dir string, state *exportState) error {
+	return writeJSON(filepath.Join(dir, stateFile), state)
+}
+
+// channelDir names a channel's directory: its name, or its ID for
+// conversations without one (DMs).
+func channelDir(ch *client.Channel) string {
+	if ch.Name == "" || ch.Name != filepath.Base(ch.Name) || strings.HasPrefix(ch.Name, ".") {
+		return ch.ID
+	}
+	return ch.Name
+}
+
+// readJSON decodes path into v. A missing file leaves v untouched.
+func readJSON(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeJSON writes v as indented JSON, replacing path atomically so an
+// interrupted export never leaves a truncated file behind.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), config.FilePerm); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// writeDays merges msgs into dir/YYYY-MM-DD.json by the UTC day of each
+// message. Messages already in a day file are replaced by the newer copy,
+// so re-exporting the same range is harmless. Messages are written as Slack
+// sent them, so fields client.Message does not model are kept.
+func writeDays(dir string, msgs []client.Message) error {
+	byDay := map[string][]client.Message{}
+	for _, m := range msgs {
+		day := tsDay(m.TS)
+		byDay[day] = append(byDay[day], m)
+	}
+	if len(byDay) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, config.DirPerm); err != nil {
+		return fmt.Errorf("creating %s: %w", dir, err)
+	}
+
+	for day, fresh := range byDay {
+		path := filepath.Join(dir, day+".json")
+		var existing []json.RawMessage
+		if err := readJSON(path, &existing); err != nil {
+			return err
+		}
+		merged := make(map[string]json.RawMessage, len(existing)+len(fresh))
+		for _, raw := range existing {
+			var m struct {
+				TS string `json:"ts"`
+			}
+			if err := json.Unmarshal(raw, &m); err != nil {
+				return fmt.Errorf("parsing %s: %w", path, err)
+			}
+			merged[m.TS] = raw
+		}
+		for _, m := range fresh {
+			raw, err := messageJSON(m)
+			if err != nil {
+				return err
+			}
+			merged[m.TS] = raw
+		}
+		order := make([]string, 0, len(merged))
+		for ts := range merged {
+			order = append(order, ts)
+		}
+		sort.Slice(order, func(i, j int) bool { return client.CompareTS(order[i], order[j]) < 0 })
+		out := make([]json.RawMessage, 0, len(order))
+		for _, ts := range order {
+			out = append(out, merged[ts])
+		}
+		if err := writeJSON(path, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// messageJSON is m as Slack sent it, or its typed fields for a message that
+// was not fetched from Slack.
+func messageJSON(m client.Message) (json.RawMessage, error) {
+	if raw := m.Raw(); len(raw) > 0 {
+		return raw, nil
+	}
+	return json.Marshal(m)
+}
+
+func tsDay(ts string) string {
+	s, _, _ := strings.Cut(ts, ".")
+	sec, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(sec, 0).UTC().Format("2006-01-02")
+}
+
+// downloadFile saves f under the uploads directory, reporting whether it
+// was fetched. Files already on disk are skipped, which makes --files
+// resumable too.
+func downloadFile(c *client.Client, dir string, f client.File) (bool, error) {
+	url := f.URLPrivateDownload
+	if url == "" {
+		url = f.URLPrivate
+	}
+	if f.ID == "" || url == "" {
+		// Deleted or restricted files carry no download URL.
+		return false, nil
+	}
+
+	name := filepath.Base(f.Name)
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		name = f.ID
+	}
+	fileDir := filepath.Join(dir, uploadsDir, f.ID)
+	path := filepath.Join(fileDir, name)
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(fileDir, config.DirPerm); err != nil {
+		return false, err
+	}
+
+	tmp := path + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, config.FilePerm)
+	if err != nil {
+		return false, err
+	}
+	if err := c.DownloadFile(url, out); err != nil {
+		_ = out.Close()
+		_ = os.Remove(tmp)
+		return false, err
+	}
+	if err := out.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return false, err
+	}
+	return true, os.Rename(tmp, path)
+}
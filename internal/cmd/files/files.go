@@ -11,6 +11,7 @@ func NewCmd() *cobra.Command {
 
 	cmd.AddCommand(newDownloadCmd())
 	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newSnippetCmd())
 
 	return cmd
 }
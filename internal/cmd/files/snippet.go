@@ -0,0 +1,111 @@
+package files
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type snippetOptions struct {
+	filetype string
+	title    string
+	filename string
+	thread   string
+	comment  string
+	stdin    io.Reader // For testing
+}
+
+func newSnippetCmd() *cobra.Command {
+	opts := &snippetOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "snippet <channel>",
+		Short: "Upload stdin as a code snippet",
+		Long: `Upload text from stdin as a snippet, shown with syntax highlighting.
+
+--filetype is a Slack file type such as go, python, javascript, shell, json
+or text; without it Slack guesses from the content.
+
+Examples:
+  slck files snippet alerts --filetype go --title "panic" < trace.txt
+  kubectl logs api-7c9f | slck files snippet incidents --thread 1234567890.123456 --comment "Logs from the crash"
+  slck files snippet incidents --thread https://example.slack.com/archives/C1234567890/p1234567890123456 < trace.txt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSnippet(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.filetype, "filetype", "", "Syntax highlighting type (e.g. go, python, shell)")
+	cmd.Flags().StringVar(&opts.title, "title", "", "Snippet title (default: the file name)")
+	cmd.Flags().StringVar(&opts.filename, "filename", "snippet.txt", "File name shown for the snippet")
+	cmd.Flags().StringVar(&opts.thread, "thread", "", "Post the snippet in this thread (timestamp, ref or permalink)")
+	cmd.Flags().StringVar(&opts.comment, "comment", "", "Message posted with the snippet")
+
+	return cmd
+}
+
+func runSnippet(channel string, opts *snippetOptions, c *client.Client) error {
+	var thread messageref.Ref
+	if opts.thread != "" {
+		var err error
+		if thread, err = messageref.ParseThread(opts.thread); err != nil {
+			return err
+		}
+	}
+
+	reader := opts.stdin
+	if reader == nil {
+		reader = os.Stdin
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("reading stdin: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return fmt.Errorf("snippet is empty: pipe the content on stdin")
+	}
+
+	filename := opts.filename
+	if filename == "" {
+		filename = "snippet.txt"
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+	if thread.ChannelID != "" && thread.ChannelID != channelID {
+		return fmt.Errorf("--thread %s is in channel %s, not %s", opts.thread, thread.ChannelID, channelID)
+	}
+
+	fileID, err := c.UploadFile(client.FileUpload{
+		Name:        filename,
+		Title:       opts.title,
+		Content:     bytes.NewReader(data),
+		Size:        int64(len(data)),
+		SnippetType: opts.filetype,
+	}, channelID, thread.TS, opts.comment)
+	if err != nil {
+		return client.WrapError("upload snippet", err)
+	}
+	c.Record(journal.Entry{Action: journal.ActionUpload, Channel: channelID, Files: []string{fileID}, Text: opts.comment})
+
+	output.Printf("Snippet %s uploaded to channel %s\n", fileID, channelID)
+	return nil
+}
@@ -0,0 +1,72 @@
+package files
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+func TestRunSnippet_Uploads(t *testing.T) {
+	var uploaded string
+	var query map[string]string
+	var complete map[string]interface{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files.getUploadURLExternal":
+			q := r.URL.Query()
+			query = map[string]string{"filename": q.Get("filename"), "length": q.Get("length"), "snippet_type": q.Get("snippet_type")}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "upload_url": server.URL + "/upload", "file_id": "F123"})
+		case "/upload":
+			data, _ := io.ReadAll(r.Body)
+			uploaded = string(data)
+		case "/files.completeUploadExternal":
+			_ = json.NewDecoder(r.Body).Decode(&complete)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	opts := &snippetOptions{
+		filetype: "go",
+		title:    "panic",
+		filename: "snippet.txt",
+		thread:   "https://example.slack.com/archives/C1234567890/p1700000000000100",
+		comment:  "from the crash",
+		stdin:    strings.NewReader("panic: boom\n"),
+	}
+	out := captureOutput(t, func() {
+		require.NoError(t, runSnippet("C1234567890", opts, c))
+	})
+
+	assert.Equal(t, map[string]string{"filename": "snippet.txt", "length": "12", "snippet_type": "go"}, query)
+	assert.Equal(t, "panic: boom\n", uploaded)
+	assert.Equal(t, "C1234567890", complete["channel_id"])
+	assert.Equal(t, "1700000000.000100", complete["thread_ts"])
+	assert.Equal(t, "from the crash", complete["initial_comment"])
+	assert.Equal(t, "panic", complete["files"].([]interface{})[0].(map[string]interface{})["title"])
+	assert.Equal(t, "Snippet F123 uploaded to channel C1234567890\n", out)
+}
+
+func TestRunSnippet_Validation(t *testing.T) {
+	err := runSnippet("C1234567890", &snippetOptions{stdin: strings.NewReader(" \n")}, nil)
+	assert.ErrorContains(t, err, "snippet is empty")
+
+	err = runSnippet("C1234567890", &snippetOptions{thread: "soon", stdin: strings.NewReader("x")}, nil)
+	assert.Error(t, err)
+
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	err = runSnippet("C1234567890", &snippetOptions{thread: "C0987654321/1700000000.000100", stdin: strings.NewReader("x")}, c)
+	assert.ErrorContains(t, err, "is in channel C0987654321, not C1234567890")
+}
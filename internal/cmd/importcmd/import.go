@@ -0,0 +1,492 @@
+package importcmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/config"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+const (
+	attributionPrefix = "prefix"
+	attributionAuthor = "author"
+	attributionNone   = "none"
+
+	defaultImportRate = 1.0 // posts per second
+
+	// maxImportAttempts bounds retries of a post that Slack rate-limited.
+	maxImportAttempts = 5
+
+	// uploadsDir is where `slck export --files` stores downloaded files.
+	uploadsDir = "__uploads"
+)
+
+// skippedSubtypes are channel events rather than conversation; replaying
+// them would only produce noise like "alice has joined the channel".
+var skippedSubtypes = map[string]bool{
+	"channel_join":      true,
+	"channel_leave":     true,
+	"channel_topic":     true,
+	"channel_purpose":   true,
+	"channel_name":      true,
+	"channel_archive":   true,
+	"channel_unarchive": true,
+	"group_join":        true,
+	"group_leave":       true,
+	"group_topic":       true,
+	"group_purpose":     true,
+	"group_name":        true,
+	"group_archive":     true,
+	"group_unarchive":   true,
+	"pinned_item":       true,
+	"unpinned_item":     true,
+	"bot_add":           true,
+	"bot_remove":        true,
+	"tombstone":         true,
+}
+
+type importOptions struct {
+	from        string
+	source      string
+	channel     string
+	attribution string
+	rate        float64
+	checkpoint  string
+}
+
+// NewCmd creates the import command
+func NewCmd() *cobra.Command {
+	opts := &importOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Replay messages from a Slack export into a channel",
+		Long: `Replay the messages of one channel from a Slack export (such as one written
+by 'slck export') into a target channel, oldest first.
+
+Threads are rebuilt: replies are posted under the new copy of their parent.
+Files downloaded with 'slck export --files' are re-uploaded next to their
+message; files missing from the export are listed by name instead. Channel
+events (joins, topic changes, ...) are skipped.
+
+--attribution controls how the original author is shown:
+  prefix  a "from @alice at 2026-10-01 09:30 UTC" line above the text (default)
+  author  post under the author's name and avatar (bot token with the
+          chat:write.customize scope)
+  none    post the text as-is
+
+Imports are resumable: every posted message is recorded in a checkpoint file
+(by default .slck-import-<source>-<channel-id>.json in the export directory),
+and a re-run skips what was already posted.
+
+Examples:
+  slck import --from out/ --channel archive-general
+  slck import --from out/ --source incidents --channel incidents-2025 --attribution author
+  slck import --from out/ --channel archive-general --rate 0.5`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runImport(opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.from, "from", "", "Export directory to import from (required)")
+	cmd.Flags().StringVar(&opts.source, "source", "", "Channel directory within the export (required if it has more than one)")
+	cmd.Flags().StringVar(&opts.channel, "channel", "", "Channel to post into (required)")
+	cmd.Flags().StringVar(&opts.attribution, "attribution", attributionPrefix, "How to show the original author: prefix, author or none")
+	cmd.Flags().Float64Var(&opts.rate, "rate", defaultImportRate, "Maximum messages posted per second")
+	cmd.Flags().StringVar(&opts.checkpoint, "checkpoint", "", "Checkpoint file (default: in the export directory)")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("channel")
+
+	return cmd
+}
+
+// checkpoint maps each imported source message ts to the ts of its copy.
+// It doubles as the thread_ts mapping for replies.
+type checkpoint struct {
+	Source  string            `json:"source"`
+	Channel string            `json:"channel"`
+	Posted  map[string]string `json:"posted"`
+}
+
+func runImport(opts *importOptions, c *client.Client) error {
+	if opts.from == "" {
+		return fmt.Errorf("--from is required")
+	}
+	if opts.channel == "" {
+		return fmt.Errorf("--channel is required")
+	}
+	switch opts.attribution {
+	case attributionPrefix, attributionAuthor, attributionNone:
+	default:
+		return fmt.Errorf("invalid --attribution %q: must be prefix, author or none", opts.attribution)
+	}
+	if opts.rate <= 0 {
+		return fmt.Errorf("--rate must be greater than 0")
+	}
+
+	source, err := sourceDir(opts.from, opts.source)
+	if err != nil {
+		return err
+	}
+	msgs, err := loadMessages(filepath.Join(opts.from, source))
+	if err != nil {
+		return err
+	}
+	users, err := loadUsers(opts.from)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(opts.channel)
+	if err != nil {
+		return err
+	}
+
+	cpPath := opts.checkpoint
+	if cpPath == "" {
+		cpPath = filepath.Join(opts.from, fmt.Sprintf(".slck-import-%s-%s.json", source, channelID))
+	}
+	cp, err := loadCheckpoint(cpPath)
+	if err != nil {
+		return err
+	}
+	cp.Source, cp.Channel = source, channelID
+
+	limiter := client.NewRateLimiter(opts.rate)
+	defer limiter.Stop()
+
+	var posted, skipped, resumed int
+	for _, m := range msgs {
+		if skippedSubtypes[m.Subtype] {
+			skipped++
+			continue
+		}
+		if _, done := cp.Posted[m.TS]; done {
+			resumed++
+			continue
+		}
+
+		// Replies go under the new copy of their parent. A reply whose
+		// parent is not in the export is posted at the top level.
+		threadTS := ""
+		if m.ThreadTS != "" && m.ThreadTS != m.TS {
+			threadTS = cp.Posted[m.ThreadTS]
+		}
+
+		localFiles, missing := exportedFiles(opts.from, m.Files)
+		text := importText(m, users, opts.attribution, missing)
+		postOpts := client.PostOptions{}
+		if opts.attribution == attributionAuthor {
+			postOpts.Username = authorName(m, users)
+			postOpts.IconURL = users[m.User].Profile.Image72
+		}
+
+		ts, err := postWithRetry(c, channelID, text, threadTS, postOpts, limiter.C)
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("import message %s (%d posted so far; re-run to resume)", m.TS, posted), err)
+		}
+		// A dry run keeps the mapping in memory, so replies still show
+		// their thread, but must not mark anything as posted on disk
+		cp.Posted[m.TS] = ts
+		if !client.DryRun() {
+			if err := saveCheckpoint(cpPath, cp); err != nil {
+				return err
+			}
+		}
+		posted++
+
+		if len(localFiles) > 0 {
+			<-limiter.C
+			if err := uploadFiles(c, channelID, threadTS, localFiles); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: could not re-upload files for message %s: %v\n", m.TS, err)
+			}
+		}
+	}
+
+	output.Printf("Imported %d messages from %s into %s", posted, source, opts.channel)
+	if skipped > 0 || resumed > 0 {
+		output.Printf(" (%d channel events skipped, %d already imported)", skipped, resumed)
+	}
+	output.Println("")
+	return nil
+}
+
+// sourceDir picks the channel directory to import. Without --source the
+// export must contain exactly one.
+func sourceDir(from, source string) (string, error) {
+	if source != "" {
+		source = strings.TrimPrefix(source, "#")
+		info, err := os.Stat(filepath.Join(from, source))
+		if err != nil || !info.IsDir() {
+			return "", fmt.Errorf("channel %q not found in export %s", source, from)
+		}
+		return source, nil
+	}
+
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return "", fmt.Errorf("reading export: %w", err)
+	}
+	var dirs []string
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != uploadsDir && !strings.HasPrefix(e.Name(), ".") {
+			dirs = append(dirs, e.Name())
+		}
+	}
+	switch len(dirs) {
+	case 0:
+		return "", fmt.Errorf("no channel directories found in export %s", from)
+	case 1:
+		return dirs[0], nil
+	default:
+		return "", fmt.Errorf("export contains %d channels (%s); choose one with --source", len(dirs), strings.Join(dirs, ", "))
+	}
+}
+
+// loadMessages reads every day file in dir, oldest first.
+func loadMessages(dir string) ([]client.Message, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	var msgs []client.Message
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		var day []client.Message
+		if err := json.Unmarshal(data, &day); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", p, err)
+		}
+		msgs = append(msgs, day...)
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("no messages found in %s", dir)
+	}
+	client.SortByTS(msgs)
+	return msgs, nil
+}
+
+// loadUsers reads the export's users.json, keyed by user ID. It is optional:
+// without it authors are shown by ID.
+func loadUsers(from string) (map[string]client.User, error) {
+	users := map[string]client.User{}
+	data, err := os.ReadFile(filepath.Join(from, "users.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return users, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading users.json: %w", err)
+	}
+	var list []client.User
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("parsing users.json: %w", err)
+	}
+	for _, u := range list {
+		users[u.ID] = u
+	}
+	return users, nil
+}
+
+func loadCheckpoint(path string) (*checkpoint, error) {
+	cp := &checkpoint{}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("reading checkpoint: %w", err)
+	default:
+		if err := json.Unmarshal(data, cp); err != nil {
+			return nil, fmt.Errorf("parsing checkpoint %s: %w", path, err)
+		}
+	}
+	if cp.Posted == nil {
+		cp.Posted = map[string]string{}
+	}
+	return cp, nil
+}
+
+// saveCheckpoint replaces the checkpoint atomically after every post, so an
+// interrupted import never re-posts a message it recorded.
+func saveCheckpoint(path string, cp *checkpoint) error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, config.FilePerm); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	return nil
+}
+
+// authorName is the display name of a message's author.
+func authorName(m client.Message, users map[string]client.User) string {
+	if u, ok := users[m.User]; ok {
+		switch {
+		case u.Profile.DisplayName != "":
+			return u.Profile.DisplayName
+		case u.RealName != "":
+			return u.RealName
+		case u.Name != "":
+			return u.Name
+		}
+	}
+	switch {
+	case m.Username != "":
+		return m.Username
+	case m.BotProfile.Name != "":
+		return m.BotProfile.Name
+	case m.User != "":
+		return m.User
+	}
+	return "unknown"
+}
+
+// importText builds the text posted for m: the attribution line, the
+// original text (or a rendering of its blocks and attachments when it has
+// none) and the names of files that cannot be re-uploaded. Mentions are
+// turned into plain names so replaying history notifies nobody.
+func importText(m client.Message, users map[string]client.User, attribution string, missing []string) string {
+	var lines []string
+	if attribution == attributionPrefix {
+		lines = append(lines, fmt.Sprintf("_from @%s at %s_", authorName(m, users), tsTime(m.TS)))
+	}
+	body := m.Text
+	if body == "" {
+		body = client.RenderMessage(client.MessageContent{Blocks: m.Blocks, Attachments: m.Attachments}, nil).Body
+	}
+	if body != "" {
+		lines = append(lines, neutralizeMentions(body, users))
+	}
+	for _, name := range missing {
+		lines = append(lines, fmt.Sprintf("[file not in export: %s]", name))
+	}
+	if len(lines) == 0 {
+		return "(empty message)"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// notifyingTokenRegex matches the tokens that notify someone when posted:
+// user mentions, @here/@channel/@everyone and user group mentions, each
+// with an optional |label.
+var notifyingTokenRegex = regexp.MustCompile(`<(@[UW][A-Z0-9]+|!here|!channel|!everyone|!subteam\^[A-Z0-9]+)(?:\|([^<>]*))?>`)
+
+// neutralizeMentions replaces notifying tokens in text with the plain
+// @names they display as.
+func neutralizeMentions(text string, users map[string]client.User) string {
+	return notifyingTokenRegex.ReplaceAllStringFunc(text, func(token string) string {
+		sub := notifyingTokenRegex.FindStringSubmatch(token)
+		target, label := sub[1], strings.TrimPrefix(sub[2], "@")
+		switch {
+		case strings.HasPrefix(target, "@"):
+			if label == "" {
+				label = authorName(client.Message{User: target[1:]}, users)
+			}
+		case strings.HasPrefix(target, "!subteam^"):
+			if label == "" {
+				label = strings.TrimPrefix(target, "!subteam^")
+			}
+		default:
+			label = target[1:]
+		}
+		return "@" + label
+	})
+}
+
+func tsTime(ts string) string {
+	s, _, _ := strings.Cut(ts, ".")
+	sec, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(sec, 0).UTC().Format("2006-01-02 15:04 MST")
+}
+
+// exportedFiles splits a message's files into local paths under the
+// export's uploads directory and the names of files that were not
+// downloaded.
+func exportedFiles(from string, files []client.File) (local, missing []string) {
+	for _, f := range files {
+		path := filepath.Join(from, uploadsDir, f.ID, filepath.Base(f.Name))
+		if f.ID != "" && f.Name != "" {
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				local = append(local, path)
+				continue
+			}
+		}
+		name := f.Name
+		if name == "" {
+			name = f.ID
+		}
+		missing = append(missing, name)
+	}
+	return local, missing
+}
+
+// postWithRetry posts one message, waiting on the shared limiter before
+// every attempt and retrying when Slack rate-limits the post.
+func postWithRetry(c *client.Client, channelID, text, threadTS string, opts client.PostOptions, limiter <-chan time.Time) (string, error) {
+	var ts string
+	err := client.WithRetry(maxImportAttempts, limiter, func() error {
+		msg, err := c.SendMessageWithOptions(channelID, text, threadTS, nil, false, opts)
+		if err == nil {
+			ts = msg.TS
+		}
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	c.Record(journal.Entry{Action: journal.ActionSend, Channel: channelID, TS: ts, Text: text})
+	return ts, nil
+}
+
+// uploadFiles re-uploads files into the channel (or thread) without a
+// comment; they appear right after the message they belonged to.
+func uploadFiles(c *client.Client, channelID, threadTS string, paths []string) error {
+	uploads := make([]client.FileUpload, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		uploads = append(uploads, client.FileUpload{Name: filepath.Base(path), Content: f, Size: info.Size()})
+	}
+	ids, err := c.UploadFiles(uploads, channelID, threadTS, "")
+	if err != nil {
+		return err
+	}
+	c.Record(journal.Entry{Action: journal.ActionUpload, Channel: channelID, Files: ids})
+	return nil
+}
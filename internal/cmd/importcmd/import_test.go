@@ -0,0 +1,242 @@
+package importcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/config"
+)
+
+// writeExport lays out a minimal export with one channel directory.
+func writeExport(t *testing.T, days map[string][]map[string]interface{}) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "general"), 0o755))
+	for day, msgs := range days {
+		data, err := json.Marshal(msgs)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "general", day+".json"), data, 0o644))
+	}
+	users, err := json.Marshal([]map[string]interface{}{{
+		"id": "U1", "name": "alice",
+		"profile": map[string]interface{}{"display_name": "Alice", "image_72": "https://example.com/alice.png"},
+	}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "users.json"), users, 0o644))
+	return dir
+}
+
+// importServer records chat.postMessage bodies and answers with
+// sequential timestamps. failAt makes the n-th post (1-based) fail.
+type importServer struct {
+	posts     []map[string]interface{}
+	completes []map[string]interface{}
+	failAt    int
+}
+
+func (s *importServer) start(t *testing.T) *client.Client {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if r.Method == http.MethodPost && r.URL.Path != "/upload" {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			if s.failAt > 0 && len(s.posts)+1 == s.failAt {
+				s.failAt = 0
+				resp = map[string]interface{}{"ok": false, "error": "internal_error"}
+				break
+			}
+			s.posts = append(s.posts, body)
+			resp["ts"] = fmt.Sprintf("1800000000.%06d", len(s.posts))
+		case "/files.getUploadURLExternal":
+			resp["upload_url"] = server.URL + "/upload"
+			resp["file_id"] = "FNEW"
+		case "/upload":
+		case "/files.completeUploadExternal":
+			s.completes = append(s.completes, body)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func texts(posts []map[string]interface{}) []string {
+	var out []string
+	for _, p := range posts {
+		out = append(out, p["text"].(string))
+	}
+	return out
+}
+
+func TestRunImport_ReplaysInOrderWithThreads(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-15": {
+			{"type": "message", "user": "U1", "text": "reply", "ts": "1700007300.000100", "thread_ts": "1700000000.000100"},
+			{"type": "message", "user": "U2", "text": "later", "ts": "1700007200.000100",
+				"files": []map[string]interface{}{{"id": "F1", "name": "report.pdf"}}},
+		},
+		"2023-11-14": {
+			{"type": "message", "subtype": "channel_join", "user": "U2", "text": "joined", "ts": "1699999999.000100"},
+			{"type": "message", "user": "U1", "text": "parent", "ts": "1700000000.000100", "reply_count": 1},
+		},
+	})
+	s := &importServer{}
+	c := s.start(t)
+
+	err := runImport(&importOptions{from: dir, channel: "C0000000001", attribution: attributionPrefix, rate: 1000}, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"_from @Alice at 2023-11-14 22:13 UTC_\nparent",
+		"_from @U2 at 2023-11-15 00:13 UTC_\nlater\n[file not in export: report.pdf]",
+		"_from @Alice at 2023-11-15 00:15 UTC_\nreply",
+	}, texts(s.posts))
+	assert.Nil(t, s.posts[0]["thread_ts"])
+	assert.Equal(t, "1800000000.000001", s.posts[2]["thread_ts"], "reply goes under the new parent")
+}
+
+func TestRunImport_NeutralizesMentions(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-14": {
+			{"type": "message", "user": "U2", "ts": "1700000000.000100",
+				"text": "<!here> <@U1> and <@U9|bob>, ask <!subteam^S1|@oncall> or <!channel>; see <#C1|general>"},
+		},
+	})
+	s := &importServer{}
+	c := s.start(t)
+
+	err := runImport(&importOptions{from: dir, channel: "C0000000001", attribution: attributionNone, rate: 1000}, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"@here @Alice and @bob, ask @oncall or @channel; see <#C1|general>"}, texts(s.posts))
+}
+
+func TestRunImport_ResumesFromCheckpoint(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-14": {
+			{"type": "message", "user": "U1", "text": "one", "ts": "1700000000.000100"},
+			{"type": "message", "user": "U1", "text": "two", "ts": "1700000001.000100"},
+			{"type": "message", "user": "U1", "text": "three", "ts": "1700000002.000100", "thread_ts": "1700000000.000100"},
+		},
+	})
+	s := &importServer{failAt: 2}
+	c := s.start(t)
+	opts := &importOptions{from: dir, channel: "C0000000001", attribution: attributionNone, rate: 1000}
+
+	err := runImport(opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "re-run to resume")
+
+	require.NoError(t, runImport(opts, c))
+	assert.Equal(t, []string{"one", "two", "three"}, texts(s.posts))
+	assert.Equal(t, "1800000000.000001", s.posts[2]["thread_ts"], "thread mapping survives the restart")
+
+	info, err := os.Stat(filepath.Join(dir, ".slck-import-general-C0000000001.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(config.FilePerm), info.Mode().Perm())
+}
+
+func TestRunImport_DryRunLeavesNoCheckpoint(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-14": {
+			{"type": "message", "user": "U1", "text": "one", "ts": "1700000000.000100"},
+			{"type": "message", "user": "U1", "text": "two", "ts": "1700000001.000100", "thread_ts": "1700000000.000100"},
+		},
+	})
+	s := &importServer{}
+	c := s.start(t)
+	opts := &importOptions{from: dir, channel: "C0000000001", attribution: attributionNone, rate: 1000}
+
+	var calls bytes.Buffer
+	client.SetDryRun(&calls)
+	defer client.SetDryRun(nil)
+	require.NoError(t, runImport(opts, c))
+	client.SetDryRun(nil)
+
+	assert.Empty(t, s.posts)
+	assert.Contains(t, calls.String(), `"thread_ts": "0000000000.000000"`)
+	assert.NoFileExists(t, filepath.Join(dir, ".slck-import-general-C0000000001.json"))
+
+	// A real run afterwards still posts everything
+	require.NoError(t, runImport(opts, c))
+	assert.Equal(t, []string{"one", "two"}, texts(s.posts))
+}
+
+func TestRunImport_AuthorAttribution(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-14": {{"type": "message", "user": "U1", "text": "hello", "ts": "1700000000.000100"}},
+	})
+	s := &importServer{}
+	c := s.start(t)
+
+	err := runImport(&importOptions{from: dir, channel: "C0000000001", attribution: attributionAuthor, rate: 1000}, c)
+	require.NoError(t, err)
+
+	require.Len(t, s.posts, 1)
+	assert.Equal(t, "hello", s.posts[0]["text"])
+	assert.Equal(t, "Alice", s.posts[0]["username"])
+	assert.Equal(t, "https://example.com/alice.png", s.posts[0]["icon_url"])
+}
+
+func TestRunImport_ReuploadsExportedFiles(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-14": {
+			{"type": "message", "user": "U1", "text": "parent", "ts": "1700000000.000100"},
+			{"type": "message", "user": "U1", "text": "see file", "ts": "1700000001.000100", "thread_ts": "1700000000.000100",
+				"files": []map[string]interface{}{{"id": "F1", "name": "report.pdf"}}},
+		},
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, uploadsDir, "F1"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, uploadsDir, "F1", "report.pdf"), []byte("pdf"), 0o644))
+	s := &importServer{}
+	c := s.start(t)
+
+	err := runImport(&importOptions{from: dir, channel: "C0000000001", attribution: attributionNone, rate: 1000}, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"parent", "see file"}, texts(s.posts))
+	require.Len(t, s.completes, 1)
+	assert.Equal(t, "1800000000.000001", s.completes[0]["thread_ts"])
+}
+
+func TestRunImport_Validation(t *testing.T) {
+	dir := writeExport(t, map[string][]map[string]interface{}{
+		"2023-11-14": {{"type": "message", "text": "x", "ts": "1700000000.000100"}},
+	})
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "random"), 0o755))
+
+	tests := []struct {
+		name string
+		opts importOptions
+		want string
+	}{
+		{"bad attribution", importOptions{from: dir, channel: "C1", attribution: "alias", rate: 1}, "invalid --attribution"},
+		{"bad rate", importOptions{from: dir, channel: "C1", attribution: attributionNone}, "--rate must be greater than 0"},
+		{"ambiguous source", importOptions{from: dir, channel: "C1", attribution: attributionNone, rate: 1}, "choose one with --source"},
+		{"unknown source", importOptions{from: dir, source: "nope", channel: "C1", attribution: attributionNone, rate: 1}, `channel "nope" not found`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runImport(&tt.opts, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package journalcmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type listOptions struct {
+	limit int
+}
+
+// NewCmd creates the journal command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "journal",
+		Short: "Review the local record of changes made through slck",
+		Long: `slck records every change it makes — messages sent, updated and deleted,
+reactions, file uploads, channel topics and purposes, archiving and
+invitations — in a journal in its state directory, with the token type used
+and what each change replaced. Bulk commands (purge, broadcast, import, pipe,
+run) record one entry per message. Use 'slck undo' to reverse an entry.
+
+Nothing is recorded under --dry-run.`,
+	}
+
+	cmd.AddCommand(newListCmd())
+
+	return cmd
+}
+
+func newListCmd() *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List recorded changes, newest last",
+		Long: `List the most recent entries in the journal, newest last.
+
+The UNDONE column shows the entry that reversed an entry.
+
+Examples:
+  slck journal list
+  slck journal list --limit 100`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(opts, nil)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Number of entries to show (0 for all)")
+
+	return cmd
+}
+
+func runList(opts *listOptions, log *journal.Log) error {
+	if opts.limit < 0 {
+		return fmt.Errorf("--limit cannot be negative")
+	}
+
+	if log == nil {
+		var err error
+		if log, err = journal.Open(); err != nil {
+			return err
+		}
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		output.Println("The journal is empty")
+		return nil
+	}
+
+	undone := journal.UndoneBy(entries)
+	if opts.limit > 0 && len(entries) > opts.limit {
+		entries = entries[len(entries)-opts.limit:]
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		undoneBy := ""
+		if id, ok := undone[e.ID]; ok {
+			undoneBy = "#" + strconv.Itoa(id)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(e.ID),
+			e.Time.Local().Format("2006-01-02 15:04:05"),
+			e.Token,
+			e.Action,
+			target(e),
+			summary(e),
+			undoneBy,
+		})
+	}
+	output.Table([]string{"ID", "TIME", "TOKEN", "ACTION", "TARGET", "DETAILS", "UNDONE"}, rows)
+	return nil
+}
+
+// target is the channel, or the channel/ts ref, an entry acted on.
+func target(e journal.Entry) string {
+	if e.TS != "" {
+		return e.Channel + "/" + e.TS
+	}
+	return e.Channel
+}
+
+// summary describes what an entry changed in one short line.
+func summary(e journal.Entry) string {
+	var s string
+	switch e.Action {
+	case journal.ActionSend:
+		s = quote(e.Text)
+	case journal.ActionUpdate, journal.ActionTopic, journal.ActionPurpose:
+		s = quote(e.Previous) + " -> " + quote(e.Text)
+	case journal.ActionDelete:
+		s = "was " + quote(e.Previous)
+	case journal.ActionReact, journal.ActionUnreact:
+		s = ":" + e.Reaction + ":"
+	case journal.ActionInvite:
+		s = strings.Join(e.Users, ", ")
+	case journal.ActionUpload:
+		s = strings.Join(e.Files, ", ")
+	}
+	if e.UndoOf != 0 {
+		s = strings.TrimSpace(fmt.Sprintf("undo of #%d %s", e.UndoOf, s))
+	}
+	return s
+}
+
+// quote shortens text to one line for the DETAILS column.
+func quote(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 40 {
+		s = string(r[:37]) + "..."
+	}
+	return strconv.Quote(s)
+}
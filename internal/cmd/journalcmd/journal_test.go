@@ -0,0 +1,195 @@
+package journalcmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	var buf strings.Builder
+	orig := output.Writer
+	output.Writer = &buf
+	defer func() { output.Writer = orig }()
+	fn()
+	return buf.String()
+}
+
+// seedJournal writes entries to a fresh journal and records into it.
+func seedJournal(t *testing.T, entries ...journal.Entry) *journal.Log {
+	t.Helper()
+	log := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	for _, e := range entries {
+		_, err := log.Append(e)
+		require.NoError(t, err)
+	}
+	client.SetJournal(log)
+	t.Cleanup(func() { client.SetJournal(nil) })
+	return log
+}
+
+// undoServer records the paths and bodies of the calls undo makes.
+func undoServer(t *testing.T, calls *[]string, bodies *[]map[string]interface{}) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.URL.Path)
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/conversations.replies":
+			resp["messages"] = []map[string]interface{}{{"type": "message", "text": "current", "ts": "1700000000.000100"}}
+		case "/conversations.info":
+			resp["channel"] = map[string]interface{}{"id": "C111"}
+		default:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*bodies = append(*bodies, body)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "xoxb-test", nil)
+}
+
+func TestRunList(t *testing.T) {
+	log := seedJournal(t,
+		journal.Entry{Token: "bot", Action: journal.ActionSend, Channel: "C111", TS: "1700000000.000100", Text: "Deploying api\nv1.4.2"},
+		journal.Entry{Token: "user", Action: journal.ActionTopic, Channel: "C111", Previous: "old", Text: "new"},
+		journal.Entry{Token: "bot", Action: journal.ActionDelete, Channel: "C111", TS: "1700000000.000100", UndoOf: 1},
+	)
+
+	out := captureOutput(t, func() {
+		require.NoError(t, runList(&listOptions{limit: 20}, log))
+	})
+	assert.Contains(t, out, `C111/1700000000.000100`)
+	assert.Contains(t, out, `"Deploying api v1.4.2"`)
+	assert.Contains(t, out, `"old" -> "new"`)
+	assert.Contains(t, out, "undo of #1")
+	assert.Contains(t, out, "#3", "the undone entry points at its undo")
+
+	out = captureOutput(t, func() {
+		require.NoError(t, runList(&listOptions{limit: 1}, log))
+	})
+	assert.NotContains(t, out, "Deploying")
+
+	out = captureOutput(t, func() {
+		require.NoError(t, runList(&listOptions{}, journal.New(filepath.Join(t.TempDir(), "none.jsonl"))))
+	})
+	assert.Contains(t, out, "The journal is empty")
+}
+
+func TestRunUndo_LatestReversible(t *testing.T) {
+	log := seedJournal(t,
+		journal.Entry{Token: "bot", Action: journal.ActionSend, Channel: "C111", TS: "1700000000.000100", Text: "hi"},
+		journal.Entry{Token: "bot", Action: journal.ActionInvite, Channel: "C111", Users: []string{"U1"}},
+	)
+	var calls []string
+	var bodies []map[string]interface{}
+	c := undoServer(t, &calls, &bodies)
+
+	out := captureOutput(t, func() {
+		require.NoError(t, runUndo("", &undoOptions{stdin: strings.NewReader("y\n")}, log, c))
+	})
+	assert.Contains(t, out, "About to undo #1 (send C111/1700000000.000100): delete message C111/1700000000.000100")
+	assert.Contains(t, out, "Undid #1")
+	assert.Contains(t, calls, "/chat.delete")
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, journal.ActionDelete, entries[2].Action)
+	assert.Equal(t, 1, entries[2].UndoOf, "the undo is journaled against the entry it reversed")
+
+	err = runUndo("", &undoOptions{force: true}, log, c)
+	assert.EqualError(t, err, "nothing to undo")
+}
+
+func TestRunUndo_NoAnswerCancels(t *testing.T) {
+	log := seedJournal(t,
+		journal.Entry{Token: "bot", Action: journal.ActionSend, Channel: "C111", TS: "1700000000.000100", Text: "hi"},
+	)
+	var calls []string
+	var bodies []map[string]interface{}
+	c := undoServer(t, &calls, &bodies)
+
+	out := captureOutput(t, func() {
+		require.NoError(t, runUndo("", &undoOptions{stdin: strings.NewReader("")}, log, c))
+	})
+	assert.Contains(t, out, "Cancelled.")
+	assert.Empty(t, calls, "nothing is changed without an answer")
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestRunUndo_RestoresPreviousValues(t *testing.T) {
+	log := seedJournal(t,
+		journal.Entry{Action: journal.ActionUpdate, Channel: "C111", TS: "1700000000.000100", Text: "new", Previous: "old", HasPrevious: true},
+		journal.Entry{Action: journal.ActionTopic, Channel: "C111", Text: "new topic", Previous: "old topic", HasPrevious: true},
+		journal.Entry{Action: journal.ActionReact, Channel: "C111", TS: "1700000000.000100", Reaction: "eyes"},
+	)
+	var calls []string
+	var bodies []map[string]interface{}
+	c := undoServer(t, &calls, &bodies)
+
+	captureOutput(t, func() {
+		require.NoError(t, runUndo("#1", &undoOptions{force: true}, log, c))
+		require.NoError(t, runUndo("2", &undoOptions{force: true}, log, c))
+		require.NoError(t, runUndo("3", &undoOptions{force: true}, log, c))
+	})
+
+	require.Len(t, bodies, 3)
+	assert.Equal(t, "old", bodies[0]["text"])
+	assert.Equal(t, []interface{}{}, bodies[0]["blocks"], "blocks the message gained are removed")
+	assert.Equal(t, "old topic", bodies[1]["topic"])
+	assert.Equal(t, "eyes", bodies[2]["name"])
+	assert.Contains(t, calls, "/reactions.remove")
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 6)
+	assert.Equal(t, "current", entries[3].Previous, "undoing an update can itself be undone")
+	assert.Equal(t, "old", entries[3].Text)
+	assert.True(t, entries[3].HasPrevious)
+	assert.Equal(t, journal.ActionUnreact, entries[5].Action)
+}
+
+func TestRunUndo_Refusals(t *testing.T) {
+	log := seedJournal(t,
+		journal.Entry{Action: journal.ActionDelete, Channel: "C111", TS: "1700000000.000100"},
+		journal.Entry{Action: journal.ActionArchive, Channel: "C111"},
+		journal.Entry{Action: journal.ActionUnarchive, Channel: "C111", UndoOf: 2},
+		journal.Entry{Action: journal.ActionUpdate, Channel: "C111", TS: "1700000000.000100", Text: "new"},
+		journal.Entry{Action: journal.ActionTopic, Channel: "C111", Text: "new topic"},
+	)
+
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"1", "#1 cannot be undone: a deleted message cannot be restored"},
+		{"2", "#2 was already undone by #3"},
+		{"4", "#4 cannot be undone: the previous text was not recorded"},
+		{"5", "#5 cannot be undone: the previous topic was not recorded"},
+		{"9", "no journal entry #9"},
+		{"x", `invalid journal ID "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			err := runUndo(tt.id, &undoOptions{force: true}, log, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package journalcmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type undoOptions struct {
+	force bool
+	stdin io.Reader // For testing
+}
+
+// NewUndoCmd creates the undo command
+func NewUndoCmd() *cobra.Command {
+	opts := &undoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "undo [id]",
+		Short: "Reverse a change recorded in the journal",
+		Long: `Reverse a change recorded in the journal ('slck journal list').
+
+Without an ID, the most recent change that can be reversed and has not been
+undone is chosen. Undoing:
+  send       deletes the message
+  update     restores the previous text and blocks
+  react      removes the reaction (unreact adds it back)
+  topic      restores the previous topic (likewise purpose)
+  archive    unarchives the channel (unarchive archives it)
+
+Deleted messages, invitations and uploads cannot be undone, nor can an update, topic
+or purpose change whose previous value could not be looked up. The undo uses
+the same token type (bot or user) as the original change, and is itself
+recorded.
+
+Examples:
+  slck undo
+  slck undo 42 --force`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := ""
+			if len(args) > 0 {
+				id = args[0]
+			}
+			return runUndo(id, opts, nil, nil)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+func runUndo(idArg string, opts *undoOptions, log *journal.Log, c *client.Client) error {
+	if log == nil {
+		var err error
+		if log, err = journal.Open(); err != nil {
+			return err
+		}
+	}
+
+	entries, err := log.Entries()
+	if err != nil {
+		return err
+	}
+
+	e, err := pickEntry(entries, idArg)
+	if err != nil {
+		return err
+	}
+	action, err := reversal(e)
+	if err != nil {
+		return fmt.Errorf("#%d cannot be undone: %w", e.ID, err)
+	}
+
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to undo #%d (%s %s): %s\n", e.ID, e.Action, target(e), action)
+		output.Printf("Are you sure? [y/N]: ")
+
+		// No answer (EOF, nothing on stdin) is not a yes
+		scanner := bufio.NewScanner(reader)
+		if !scanner.Scan() {
+			output.Println("Cancelled.")
+			return nil
+		}
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if confirm != "y" && confirm != "yes" {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	if c == nil {
+		// A bot cannot delete or edit what a user token posted, and vice versa
+		switch e.Token {
+		case "user":
+			c, err = client.NewUserClient()
+		case "bot":
+			c, err = client.NewBotClient()
+		default:
+			c, err = client.New()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	c.SetUndoOf(e.ID)
+	if err := apply(c, e); err != nil {
+		return client.WrapError(fmt.Sprintf("undo #%d", e.ID), err)
+	}
+
+	output.Printf("Undid #%d: %s\n", e.ID, action)
+	return nil
+}
+
+// pickEntry returns the entry with the given ID, or, without one, the most
+// recent entry that can be undone. Undos themselves are never picked by
+// default, so repeated 'slck undo' walks back through history.
+func pickEntry(entries []journal.Entry, idArg string) (journal.Entry, error) {
+	undone := journal.UndoneBy(entries)
+
+	if idArg != "" {
+		id, err := strconv.Atoi(strings.TrimPrefix(idArg, "#"))
+		if err != nil {
+			return journal.Entry{}, fmt.Errorf("invalid journal ID %q", idArg)
+		}
+		for _, e := range entries {
+			if e.ID != id {
+				continue
+			}
+			if by, ok := undone[id]; ok {
+				return journal.Entry{}, fmt.Errorf("#%d was already undone by #%d", id, by)
+			}
+			return e, nil
+		}
+		return journal.Entry{}, fmt.Errorf("no journal entry #%d; see 'slck journal list'", id)
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if _, ok := undone[e.ID]; ok || e.UndoOf != 0 {
+			continue
+		}
+		if _, err := reversal(e); err == nil {
+			return e, nil
+		}
+	}
+	return journal.Entry{}, fmt.Errorf("nothing to undo")
+}
+
+// reversal describes what undoing e does, or why it cannot be undone.
+func reversal(e journal.Entry) (string, error) {
+	switch e.Action {
+	case journal.ActionSend:
+		return "delete message " + target(e), nil
+	case journal.ActionUpdate:
+		if !e.HasPrevious {
+			return "", fmt.Errorf("the previous text was not recorded")
+		}
+		return "restore the previous text of " + target(e), nil
+	case journal.ActionReact:
+		return fmt.Sprintf("remove :%s: from %s", e.Reaction, target(e)), nil
+	case journal.ActionUnreact:
+		return fmt.Sprintf("add :%s: to %s", e.Reaction, target(e)), nil
+	case journal.ActionTopic, journal.ActionPurpose:
+		if !e.HasPrevious {
+			return "", fmt.Errorf("the previous %s was not recorded", e.Action)
+		}
+		return fmt.Sprintf("set the %s of %s back to %s", e.Action, e.Channel, strconv.Quote(e.Previous)), nil
+	case journal.ActionArchive:
+		return "unarchive " + e.Channel, nil
+	case journal.ActionUnarchive:
+		return "archive " + e.Channel, nil
+	case journal.ActionDelete:
+		return "", fmt.Errorf("a deleted message cannot be restored")
+	case journal.ActionInvite:
+		return "", fmt.Errorf("invitations are not reversed; remove the users in Slack")
+	case journal.ActionUpload:
+		return "", fmt.Errorf("uploaded files are not removed; delete them in Slack")
+	default:
+		return "", fmt.Errorf("unknown action %q", e.Action)
+	}
+}
+
+// apply performs the reversal of e described by reversal and records it.
+func apply(c *client.Client, e journal.Entry) error {
+	var undo journal.Entry
+	var err error
+	switch e.Action {
+	case journal.ActionSend:
+		undo = c.MessageEntry(journal.ActionDelete, e.Channel, e.TS)
+		err = c.DeleteMessage(e.Channel, e.TS)
+	case journal.ActionUpdate:
+		undo = c.MessageEntry(journal.ActionUpdate, e.Channel, e.TS)
+		undo.Text = e.Previous
+		err = c.RestoreMessage(e.Channel, e.TS, e.Previous, e.PreviousBlocks)
+	case journal.ActionReact:
+		undo = journal.Entry{Action: journal.ActionUnreact, Channel: e.Channel, TS: e.TS, Reaction: e.Reaction}
+		err = c.RemoveReaction(e.Channel, e.TS, e.Reaction)
+	case journal.ActionUnreact:
+		undo = journal.Entry{Action: journal.ActionReact, Channel: e.Channel, TS: e.TS, Reaction: e.Reaction}
+		err = c.AddReaction(e.Channel, e.TS, e.Reaction)
+	case journal.ActionTopic:
+		undo = c.ChannelEntry(journal.ActionTopic, e.Channel)
+		undo.Text = e.Previous
+		err = c.SetChannelTopic(e.Channel, e.Previous)
+	case journal.ActionPurpose:
+		undo = c.ChannelEntry(journal.ActionPurpose, e.Channel)
+		undo.Text = e.Previous
+		err = c.SetChannelPurpose(e.Channel, e.Previous)
+	case journal.ActionArchive:
+		undo = journal.Entry{Action: journal.ActionUnarchive, Channel: e.Channel}
+		err = c.UnarchiveChannel(e.Channel)
+	case journal.ActionUnarchive:
+		undo = journal.Entry{Action: journal.ActionArchive, Channel: e.Channel}
+		err = c.ArchiveChannel(e.Channel)
+	default:
+		return fmt.Errorf("unknown action %q", e.Action)
+	}
+	if err != nil {
+		return err
+	}
+	c.Record(undo)
+	return nil
+}
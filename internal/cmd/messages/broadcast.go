@@ -0,0 +1,168 @@
+package messages
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+const (
+	defaultBroadcastConcurrency = 4
+	defaultBroadcastRate        = 4.0 // posts per second
+
+	// maxBroadcastAttempts bounds retries of a post that Slack rate-limited.
+	maxBroadcastAttempts = 3
+)
+
+// broadcastTarget is one resolved --to destination.
+type broadcastTarget struct {
+	destination string // as the user wrote it
+	channelID   string
+}
+
+type broadcastResult struct {
+	broadcastTarget
+	ts        string
+	permalink string
+	err       error
+}
+
+// broadcastDestinations merges --to values with the lines of --to-file.
+// Blank lines in the file are ignored.
+func broadcastDestinations(to []string, toFile string) ([]string, error) {
+	dests := make([]string, 0, len(to))
+	for _, d := range to {
+		if d = strings.TrimSpace(d); d != "" {
+			dests = append(dests, d)
+		}
+	}
+	if toFile != "" {
+		f, err := os.Open(toFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading destinations file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			if d := strings.TrimSpace(scanner.Text()); d != "" {
+				dests = append(dests, d)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("reading destinations file: %w", err)
+		}
+	}
+	if len(dests) == 0 {
+		return nil, fmt.Errorf("no destinations given via --to or --to-file")
+	}
+	return dests, nil
+}
+
+// runBroadcast resolves every destination, then posts the same message to
+// each with bounded concurrency and a shared rate limit. Nothing is sent if
+// any destination fails to resolve.
+func runBroadcast(destinations []string, text string, blocks []interface{}, opts *sendOptions, c *client.Client) error {
+	targets, err := resolveBroadcastTargets(c, destinations)
+	if err != nil {
+		return err
+	}
+
+	limiter := client.NewRateLimiter(opts.rate)
+	defer limiter.Stop()
+
+	results := make([]broadcastResult, len(targets))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(opts.concurrency, len(targets)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = postBroadcast(c, targets[i], text, blocks, opts, limiter.C)
+			}
+		}()
+	}
+	for i := range targets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	headers := []string{"DESTINATION", "CHANNEL", "STATUS", "PERMALINK"}
+	rows := make([][]string, 0, len(results))
+	var retry []string
+	for _, r := range results {
+		if r.err != nil {
+			rows = append(rows, []string{r.destination, r.channelID, "failed: " + r.err.Error(), ""})
+			retry = append(retry, "--to "+r.destination)
+			continue
+		}
+		rows = append(rows, []string{r.destination, r.channelID, "sent", r.permalink})
+	}
+	output.Table(headers, rows)
+
+	if len(retry) > 0 {
+		return fmt.Errorf("%d of %d destinations failed; retry them with:\n  %s",
+			len(retry), len(results), strings.Join(retry, " "))
+	}
+	return nil
+}
+
+// resolveBroadcastTargets resolves destinations to conversation IDs,
+// dropping duplicates that resolve to the same conversation. All
+// resolution failures are reported together.
+func resolveBroadcastTargets(c *client.Client, destinations []string) ([]broadcastTarget, error) {
+	var targets []broadcastTarget
+	var failures []string
+	seen := map[string]string{}
+	for _, d := range destinations {
+		id, err := c.ResolveMessageDestination(d)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("  %s: %v", d, err))
+			continue
+		}
+		if first, dup := seen[id]; dup {
+			fmt.Fprintf(os.Stderr, "warning: skipping %s, same conversation as %s\n", d, first)
+			continue
+		}
+		seen[id] = d
+		targets = append(targets, broadcastTarget{destination: d, channelID: id})
+	}
+	if len(failures) > 0 {
+		return nil, fmt.Errorf("could not resolve %d destination(s); nothing was sent:\n%s",
+			len(failures), strings.Join(failures, "\n"))
+	}
+	return targets, nil
+}
+
+// postBroadcast sends one copy of the message, waiting on the shared limiter
+// before every attempt and retrying when Slack rate-limits the post. The
+// permalink lookup is best-effort.
+func postBroadcast(c *client.Client, t broadcastTarget, text string, blocks []interface{}, opts *sendOptions, limiter <-chan time.Time) broadcastResult {
+	res := broadcastResult{broadcastTarget: t}
+	res.err = client.WithRetry(maxBroadcastAttempts, limiter, func() error {
+		msg, err := c.SendMessageWithOptions(t.channelID, text, "", blocks, !opts.noUnfurl, opts.postOptions())
+		if err == nil {
+			res.ts = msg.TS
+		}
+		return err
+	})
+	if res.err != nil {
+		return res
+	}
+	c.Record(journal.Entry{Action: journal.ActionSend, Channel: t.channelID, TS: res.ts, Text: text})
+	if client.IsDryRunPlaceholder(res.ts) {
+		return res
+	}
+	if link, err := c.GetPermalink(t.channelID, res.ts); err == nil {
+		res.permalink = link
+	}
+	return res
+}
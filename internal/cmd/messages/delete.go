@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
@@ -23,11 +24,22 @@ func newDeleteCmd() *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <channel> <timestamp>",
+		Use:   "delete {<ref> | <channel> <timestamp>}",
 		Short: "Delete a message",
-		Args:  cobra.ExactArgs(2),
+		Long: `Delete a message.
+
+` + messageRefHelp + `
+
+Examples:
+  slck messages delete C1234567890 1234567890.123456
+  slck messages delete https://example.slack.com/archives/C1234567890/p1234567890123456 --force`,
+		Args: messageArgs(0, 0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(args[0], args[1], opts, nil)
+			channel, timestamp, _, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return runDelete(channel, timestamp, opts, nil)
 		},
 	}
 
@@ -76,9 +88,11 @@ func runDelete(channel, timestamp string, opts *deleteOptions, c *client.Client)
 		return err
 	}
 
+	entry := c.MessageEntry(journal.ActionDelete, channelID, timestamp)
 	if err := c.DeleteMessage(channelID, timestamp); err != nil {
 		return client.WrapError(fmt.Sprintf("delete message %s", timestamp), err)
 	}
+	c.Record(entry)
 
 	output.Println("Message deleted")
 	return nil
@@ -0,0 +1,78 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorScissors marks the start of the help text in the file opened by
+// --edit; it and everything below it are dropped, as with git's scissors
+// cleanup, so Markdown headings above it survive.
+const editorScissors = "# ------------------------ >8 ------------------------"
+
+// runEditor opens path in the user's editor and waits for it to exit. A
+// variable so tests can stand in for an interactive editor.
+var runEditor = func(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// $EDITOR may carry arguments, e.g. "code --wait"
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor %q failed: %w", editor, err)
+	}
+	return nil
+}
+
+// editMessage opens initial in the user's editor, below which help is shown
+// after a scissors line, and returns the saved text. It fails when the text
+// is left empty or unchanged, so quitting the editor aborts.
+func editMessage(initial, help string) (string, error) {
+	f, err := os.CreateTemp("", "slck-message-*.md")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	content := initial + "\n\n" + editorScissors + "\n" + help
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if err := runEditor(path); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading edited message: %w", err)
+	}
+	text := string(data)
+	if i := strings.Index(text, editorScissors); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSpace(text)
+
+	switch text {
+	case "":
+		return "", fmt.Errorf("aborted: the message is empty")
+	case strings.TrimSpace(initial):
+		return "", fmt.Errorf("aborted: the message is unchanged")
+	}
+	return text, nil
+}
@@ -1,16 +1,25 @@
 package messages
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
 
 type historyOptions struct {
-	limit  int
-	oldest string
-	latest string
+	limit        int
+	oldest       string
+	latest       string
+	all          bool
+	since        string
+	until        string
+	withReplies  bool
+	metadataType string
 }
 
 func newHistoryCmd() *cobra.Command {
@@ -19,7 +28,21 @@ func newHistoryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "history <channel>",
 		Short: "Get channel message history",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get channel message history, oldest first.
+
+--since and --until take a relative age ("30m", "12h", "7d", "2w"), a date
+("2026-10-01", local midnight), a date and time ("2026-10-01 15:04"), or a
+Slack timestamp. --all pages through the whole range instead of stopping at
+--limit, and --with-replies prints each thread's replies under its parent.
+
+Message metadata is shown under the message. --metadata-type keeps only
+messages carrying that event type, e.g. to find a bot's own announcements.
+
+Examples:
+  slck messages history incidents --since 7d --all
+  slck messages history incidents --since 2026-10-01 --until 2026-10-02 --all --with-replies
+  slck messages history deploys --since 1d --all --metadata-type deploy_started`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runHistory(args[0], opts, nil)
 		},
@@ -28,13 +51,22 @@ func newHistoryCmd() *cobra.Command {
 	cmd.Flags().IntVar(&opts.limit, "limit", 20, "Maximum messages to return")
 	cmd.Flags().StringVar(&opts.oldest, "oldest", "", "Only messages after this timestamp")
 	cmd.Flags().StringVar(&opts.latest, "latest", "", "Only messages before this timestamp")
+	cmd.Flags().BoolVar(&opts.all, "all", false, "Fetch every message in the range (ignores --limit)")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only messages after this time (e.g. 7d, 2026-10-01)")
+	cmd.Flags().StringVar(&opts.until, "until", "", "Only messages before this time (e.g. 1h, 2026-10-02)")
+	cmd.Flags().BoolVar(&opts.withReplies, "with-replies", false, "Include thread replies under their parent messages")
+	cmd.Flags().StringVar(&opts.metadataType, "metadata-type", "", "Only messages with this metadata event type")
 
 	return cmd
 }
 
 func runHistory(channel string, opts *historyOptions, c *client.Client) error {
+	oldest, latest, err := historyRange(opts, time.Now())
+	if err != nil {
+		return err
+	}
+
 	if c == nil {
-		var err error
 		c, err = client.New()
 		if err != nil {
 			return err
@@ -47,33 +79,99 @@ func runHistory(channel string, opts *historyOptions, c *client.Client) error {
 		return err
 	}
 
-	messages, err := c.GetChannelHistory(channelID, opts.limit, opts.oldest, opts.latest)
+	limit := opts.limit
+	if opts.all {
+		limit = 0
+	}
+	messages, err := c.GetChannelHistory(channelID, limit, oldest, latest)
 	if err != nil {
 		return err
 	}
 
+	if opts.metadataType != "" {
+		messages = withMetadataType(messages, opts.metadataType)
+	}
+
 	if len(messages) == 0 {
 		output.Println("No messages found")
 		return nil
 	}
 
+	client.SortByTS(messages)
 	resolver := client.NewUserResolver(c)
 	for _, m := range messages {
-		ts := formatTimestamp(m.TS)
-		// Compact view — truncation inherently flattens, so the
-		// blocks-vs-text distinction doesn't matter here.
-		body, _ := messageBody(m, resolver)
-		text := truncate(body, 80)
-		name := messageAuthor(m, resolver)
-		edited := ""
-		if m.Edited != nil {
-			edited = " [edited]"
+		printHistoryLine(m, resolver, "")
+		if !opts.withReplies || m.ReplyCount == 0 {
+			continue
+		}
+		replies, err := c.GetThreadReplies(channelID, m.TS, 0, "")
+		if err != nil {
+			return client.WrapError(fmt.Sprintf("get replies for %s", m.TS), err)
 		}
-		output.Printf("[%s] %s: %s%s\n", ts, name, text, edited)
-		if files := renderFiles(m.Files); files != "" {
-			output.Printf("%s", files)
+		for _, r := range replies {
+			if r.TS != m.TS {
+				printHistoryLine(r, resolver, "  ↳ ")
+			}
 		}
 	}
 
 	return nil
 }
+
+// historyRange resolves --since/--until (or the raw --oldest/--latest) into
+// Slack timestamps.
+func historyRange(opts *historyOptions, now time.Time) (oldest, latest string, err error) {
+	if opts.since != "" && opts.oldest != "" {
+		return "", "", fmt.Errorf("--since and --oldest cannot be used together")
+	}
+	if opts.until != "" && opts.latest != "" {
+		return "", "", fmt.Errorf("--until and --latest cannot be used together")
+	}
+	oldest, latest = opts.oldest, opts.latest
+	if opts.since != "" {
+		if oldest, err = validate.ParseTimeBound(opts.since, now); err != nil {
+			return "", "", fmt.Errorf("--since: %w", err)
+		}
+	}
+	if opts.until != "" {
+		if latest, err = validate.ParseTimeBound(opts.until, now); err != nil {
+			return "", "", fmt.Errorf("--until: %w", err)
+		}
+	}
+	if oldest != "" && latest != "" && client.CompareTS(oldest, latest) >= 0 {
+		return "", "", fmt.Errorf("the start of the range must be before the end")
+	}
+	return oldest, latest, nil
+}
+
+// printHistoryLine prints history's compact one-line view of a message.
+func printHistoryLine(m client.Message, resolver *client.UserResolver, prefix string) {
+	ts := formatTimestamp(m.TS)
+	// Compact view — truncation inherently flattens, so the
+	// blocks-vs-text distinction doesn't matter here.
+	body, _ := messageBody(m, resolver)
+	text := truncate(body, 80)
+	name := messageAuthor(m, resolver)
+	edited := ""
+	if m.Edited != nil {
+		edited = " [edited]"
+	}
+	output.Printf("%s[%s] %s: %s%s\n", prefix, ts, name, text, edited)
+	if files := renderFiles(m.Files); files != "" {
+		output.Printf("%s", files)
+	}
+	if md := renderMetadata(m.Metadata); md != "" {
+		output.Printf("%s", md)
+	}
+}
+
+// withMetadataType keeps the messages whose metadata has the event type.
+func withMetadataType(msgs []client.Message, eventType string) []client.Message {
+	var out []client.Message
+	for _, m := range msgs {
+		if m.Metadata != nil && m.Metadata.EventType == eventType {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -1,7 +1,10 @@
 package messages
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -10,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -30,10 +34,56 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(newReactCmd())
 	cmd.AddCommand(newUnreactCmd())
 	cmd.AddCommand(newPermalinkCmd())
+	cmd.AddCommand(newTailCmd())
+	cmd.AddCommand(newPurgeCmd())
+	cmd.AddCommand(newReactionsCmd())
+	cmd.AddCommand(newWaitCmd())
+	cmd.AddCommand(newShareCmd())
 
 	return cmd
 }
 
+// messageRefHelp describes the two ways a command can target a message.
+const messageRefHelp = `The message can be given as a ref from the REF column of 'slck search'
+(C1234567890/1234567890.123456), as a permalink
+(https://example.slack.com/archives/C1234567890/p1234567890123456), or as a
+channel and timestamp in two arguments.`
+
+// isMessageRef reports whether a leading argument is a message ref or
+// permalink rather than a channel. Channel names and IDs never contain "/".
+func isMessageRef(arg string) bool {
+	return strings.Contains(arg, "/")
+}
+
+// messageArgs validates the argument count of a message-targeting command:
+// the message (one ref, or a channel and timestamp) followed by between
+// minRest and maxRest further arguments.
+func messageArgs(minRest, maxRest int) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) > 0 && isMessageRef(args[0]) {
+			return cobra.RangeArgs(1+minRest, 1+maxRest)(cmd, args)
+		}
+		return cobra.RangeArgs(2+minRest, 2+maxRest)(cmd, args)
+	}
+}
+
+// splitMessageArgs returns the channel and timestamp a command targets and
+// the arguments after them. Refs are parsed into a conversation ID and API
+// timestamp; the two-argument form is returned as given.
+func splitMessageArgs(args []string) (channel, ts string, rest []string, err error) {
+	if len(args) > 0 && isMessageRef(args[0]) {
+		ref, err := messageref.Parse(args[0])
+		if err != nil {
+			return "", "", nil, err
+		}
+		return ref.ChannelID, ref.TS, args[1:], nil
+	}
+	if len(args) < 2 {
+		return "", "", nil, fmt.Errorf("expected a message ref or a channel and timestamp")
+	}
+	return args[0], args[1], args[2:], nil
+}
+
 // formatTimestamp converts a Slack timestamp to a human-readable format
 func formatTimestamp(ts string) string {
 	// Slack timestamps are Unix timestamps with decimals
@@ -101,6 +151,19 @@ func indentContinuation(s string) string {
 	return strings.ReplaceAll(s, "\n", "\n\t")
 }
 
+// renderMetadata renders message metadata as an indented line with the
+// payload as compact JSON, or "" when the message has none.
+func renderMetadata(md *client.Metadata) string {
+	if md == nil {
+		return ""
+	}
+	payload, err := json.Marshal(md.EventPayload)
+	if err != nil || md.EventPayload == nil {
+		payload = []byte("{}")
+	}
+	return fmt.Sprintf("\t[metadata] %s %s\n", md.EventType, payload)
+}
+
 // renderFiles returns one tab-indented "[file] ..." line per attachment, each
 // terminated with "\n". Returns "" when files is empty. The format gives a
 // reader (human or agent) enough context to invoke `slck files download <id>`.
@@ -155,6 +218,59 @@ const maxSectionTextLen = 3000
 // See: https://docs.slack.dev/changelog/2018-truncating-really-long-messages/
 const maxMessageTextLen = 40000
 
+// markdownBlocks converts Markdown with client.MarkdownToBlocks and enforces
+// the per-message block limit the converter leaves to its callers.
+func markdownBlocks(md string) ([]interface{}, error) {
+	blocks := client.MarkdownToBlocks(md)
+	if len(blocks) > client.MaxBlocksPerMessage {
+		return nil, fmt.Errorf(
+			"markdown converts to %d blocks, which exceeds Slack's %d-block message limit\n\n"+
+				"Alternatives:\n"+
+				"  Split the document into several messages\n"+
+				"  slck canvas create --file <path>  Create a Slack canvas instead",
+			len(blocks), client.MaxBlocksPerMessage,
+		)
+	}
+	return blocks, nil
+}
+
+// parseBlocks decodes user-supplied Block Kit JSON and, unless skipValidation
+// is set, checks it locally so mistakes surface with a JSON path instead of
+// Slack's generic invalid_blocks error.
+func parseBlocks(src string, skipValidation bool) ([]interface{}, error) {
+	blocks, err := client.ParseBlocks([]byte(src))
+	if err != nil {
+		return nil, fmt.Errorf("invalid blocks JSON: %w", err)
+	}
+	if skipValidation {
+		return blocks, nil
+	}
+	if err := client.ValidateBlocks(blocks); err != nil {
+		return nil, fmt.Errorf("invalid blocks (use --no-validate to send anyway):\n%w", err)
+	}
+	return blocks, nil
+}
+
+// readMarkdownFile reads a --markdown-file argument. "-" reads from stdin
+// (or the injected reader in tests).
+func readMarkdownFile(path string, stdin io.Reader) (string, error) {
+	if path == "-" {
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("reading markdown from stdin: %w", err)
+		}
+		return string(data), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading markdown file: %w", err)
+	}
+	return string(data), nil
+}
+
 // validateMessageLength checks whether text exceeds Slack's message length limit.
 // The forUpdate parameter controls which alternatives are suggested in the error message.
 func validateMessageLength(text string, forUpdate bool) error {
@@ -178,7 +294,7 @@ func validateMessageLength(text string, forUpdate bool) error {
 			"Alternatives:\n"+
 			"  --file <path>       Upload as a file attachment (no length limit)\n"+
 			"  slck canvas create  Create a Slack canvas instead\n\n"+
-			"To split into multiple messages, write the content to a file and chunk it yourself",
+			"Text sent to a single destination without blocks is split into several messages automatically",
 		charCount, maxMessageTextLen,
 	)
 }
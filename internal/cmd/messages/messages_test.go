@@ -1,17 +1,26 @@
 package messages
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
@@ -1623,12 +1632,12 @@ func TestValidateMessageLength_UpdateOmitsFileHint(t *testing.T) {
 	assert.Contains(t, err.Error(), "canvas create")
 }
 
-func TestRunSend_MessageTooLong(t *testing.T) {
+func TestRunSend_BroadcastMessageTooLong(t *testing.T) {
 	c := client.NewWithConfig("http://localhost", "test-token", nil)
-	opts := &sendOptions{simple: true}
+	opts := &sendOptions{simple: true, to: []string{"C123", "C456"}, concurrency: 1, rate: 1}
 
 	longText := strings.Repeat("x", maxMessageTextLen+1)
-	err := runSend("C123", longText, opts, c)
+	err := runSend("", longText, opts, c)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "exceeds Slack's 40000 character limit")
 }
@@ -2665,3 +2674,1666 @@ func TestRunRead_EmptyMessages(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, buf.String(), "No messages found")
 }
+
+func TestRunSend_Markdown(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{markdown: true}
+
+	err := runSend("C123456789", "# Deploy\n\n- api\n- worker", opts, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Deploy\n\n- api\n- worker", receivedBody["text"])
+	blocks := receivedBody["blocks"].([]interface{})
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "header", blocks[0].(map[string]interface{})["type"])
+	assert.Equal(t, "rich_text", blocks[1].(map[string]interface{})["type"])
+}
+
+func TestRunSend_MarkdownFile(t *testing.T) {
+	path := t.TempDir() + "/report.md"
+	require.NoError(t, os.WriteFile(path, []byte("## Report\n\n```\nok\n```\n"), 0o600))
+
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runSend("C123456789", "", &sendOptions{mdFile: path}, c)
+	require.NoError(t, err)
+
+	blocks := receivedBody["blocks"].([]interface{})
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "header", blocks[0].(map[string]interface{})["type"])
+}
+
+func TestRunSend_MarkdownFileStdin(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{mdFile: "-", stdin: strings.NewReader("**bold** text")}
+	require.NoError(t, runSend("C123456789", "", opts, c))
+
+	section := receivedBody["blocks"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "*bold* text", section["text"].(map[string]interface{})["text"])
+}
+
+func TestRunSend_MarkdownConflicts(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	tests := []struct {
+		name string
+		text string
+		opts *sendOptions
+		want string
+	}{
+		{"blocks", "x", &sendOptions{markdown: true, blocksJSON: "[]"}, "--markdown cannot be combined with --blocks"},
+		{"simple", "x", &sendOptions{markdown: true, simple: true}, "--markdown cannot be combined with --simple"},
+		{"file", "x", &sendOptions{markdown: true, files: []string{"a.txt"}}, "--markdown cannot be combined with --file"},
+		{"text and file", "x", &sendOptions{mdFile: "a.md"}, "cannot use message text and --markdown-file together"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSend("C123", tt.text, tt.opts, c)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRunSend_BroadcastMarkdownTooManyBlocks(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	md := strings.Repeat("# heading\n\n", client.MaxBlocksPerMessage+1)
+	err := runSend("", md, &sendOptions{markdown: true, to: []string{"C123", "C456"}, concurrency: 1, rate: 1}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "51 blocks")
+}
+
+func TestRunUpdate_MarkdownFile(t *testing.T) {
+	path := t.TempDir() + "/update.md"
+	require.NoError(t, os.WriteFile(path, []byte("# Updated\n\nbody"), 0o600))
+
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.update", r.URL.Path)
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runUpdate("C123456789", "1234567890.123456", "", &updateOptions{mdFile: path}, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Updated\n\nbody", receivedBody["text"])
+	blocks := receivedBody["blocks"].([]interface{})
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "header", blocks[0].(map[string]interface{})["type"])
+}
+
+func TestRunUpdate_EmptyText(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	err := runUpdate("C123", "1234567890.123456", "", &updateOptions{}, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "message text cannot be empty")
+}
+
+func TestRunSend_BlocksValidatedLocally(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	opts := &sendOptions{blocksJSON: `[{"type":"divider"},{"type":"header","text":{"type":"mrkdwn","text":"x"}}]`}
+
+	err := runSend("C123", "", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blocks[1].text.type: must be plain_text")
+	assert.Contains(t, err.Error(), "--no-validate")
+}
+
+func TestRunSend_NoValidate(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{blocksJSON: `[{"type":"future_block"}]`, noValidate: true}
+
+	require.NoError(t, runSend("C123", "", opts, c))
+	assert.Len(t, receivedBody["blocks"], 1)
+}
+
+func TestRunUpdate_BlocksValidatedLocally(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	opts := &updateOptions{blocksJSON: `{"blocks":[{"type":"section"}]}`}
+
+	err := runUpdate("C123", "1234567890.123456", "", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blocks[0]: section requires text or fields")
+}
+
+// writeTemplateFiles writes name->content pairs into a temp dir and returns
+// the dir.
+func writeTemplateFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestRunSend_TemplateBlocks(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"members": []map[string]interface{}{{"id": "U111", "name": "alice"}},
+			})
+		case "/chat.postMessage":
+			_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	dir := writeTemplateFiles(t, map[string]string{
+		"deploy.json": `[{"type":"header","text":{"type":"plain_text","text":{{ json (printf "Deploy %s" .version) }}}},
+{"type":"section","text":{"type":"mrkdwn","text":{{ json (printf "%s by %s" (escape .summary) (user .owner)) }}}}]`,
+		"vars.yml": "version: 1.4.2\nsummary: \"fix <script> & \\\"quotes\\\"\"\nowner: \"@alice\"\n",
+	})
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{
+		template: filepath.Join(dir, "deploy.json"),
+		dataFile: filepath.Join(dir, "vars.yml"),
+		sets:     []string{"version=1.5.0"},
+	}
+	require.NoError(t, runSend("C123456789", "", opts, c))
+
+	blocks := receivedBody["blocks"].([]interface{})
+	require.Len(t, blocks, 2)
+	header := blocks[0].(map[string]interface{})["text"].(map[string]interface{})
+	assert.Equal(t, "Deploy 1.5.0", header["text"])
+	section := blocks[1].(map[string]interface{})["text"].(map[string]interface{})
+	assert.Equal(t, `fix &lt;script&gt; &amp; "quotes" by <@U111>`, section["text"])
+}
+
+func TestRunSend_TemplateText(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	dir := writeTemplateFiles(t, map[string]string{
+		"incident.md": "# Incident {{ .id }}\nSeverity: {{ .sev | default \"unknown\" }} in {{ channel \"C0OPS1234\" }}",
+	})
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{
+		template: filepath.Join(dir, "incident.md"),
+		sets:     []string{"id=42", "sev="},
+		markdown: true,
+	}
+	require.NoError(t, runSend("C123456789", "", opts, c))
+
+	assert.Equal(t, "# Incident 42\nSeverity: unknown in <#C0OPS1234>", receivedBody["text"])
+	blocks := receivedBody["blocks"].([]interface{})
+	assert.Equal(t, "header", blocks[0].(map[string]interface{})["type"])
+}
+
+func TestRunSend_TemplateDefaultForMissingKey(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	dir := writeTemplateFiles(t, map[string]string{
+		"eta.txt": `ETA: {{ default "tbd" (index . "eta") }}`,
+	})
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	require.NoError(t, runSend("C123456789", "", &sendOptions{template: filepath.Join(dir, "eta.txt")}, c))
+	assert.Equal(t, "ETA: tbd", receivedBody["text"])
+
+	require.NoError(t, runSend("C123456789", "", &sendOptions{template: filepath.Join(dir, "eta.txt"), sets: []string{"eta=noon"}}, c))
+	assert.Equal(t, "ETA: noon", receivedBody["text"])
+}
+
+func TestRunSend_TemplateErrors(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"a.json":  `[{"type":"divider"}]`,
+		"b.txt":   "Hello {{ .name }}",
+		"bad.txt": "Hello {{ .name ",
+	})
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+
+	tests := []struct {
+		name string
+		text string
+		opts *sendOptions
+		want string
+	}{
+		{"data without template", "", &sendOptions{sets: []string{"a=b"}}, "--data and --set require --template"},
+		{"with blocks", "", &sendOptions{template: filepath.Join(dir, "a.json"), blocksJSON: "[]"}, "--template cannot be combined with --blocks"},
+		{"with text", "hi", &sendOptions{template: filepath.Join(dir, "b.txt")}, "cannot use message text and --template together"},
+		{"markdown json", "", &sendOptions{template: filepath.Join(dir, "a.json"), markdown: true}, "--markdown cannot be combined with a Block Kit"},
+		{"missing key", "", &sendOptions{template: filepath.Join(dir, "b.txt")}, "rendering template"},
+		{"parse error", "", &sendOptions{template: filepath.Join(dir, "bad.txt")}, "parsing template"},
+		{"bad set", "", &sendOptions{template: filepath.Join(dir, "b.txt"), sets: []string{"name"}}, "expected key=value"},
+		{"missing file", "", &sendOptions{template: filepath.Join(dir, "nope.txt")}, "reading template"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSend("C123456789", tt.text, tt.opts, c)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+// broadcastServer fakes the endpoints a broadcast touches. Posts to a
+// channel listed in fail answer with that Slack error code.
+func broadcastServer(t *testing.T, fail map[string]string, posted *[]string) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.open":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true, "channel": map[string]interface{}{"id": "D0000000333"},
+			})
+		case "/chat.postMessage":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			ch := body["channel"].(string)
+			mu.Lock()
+			*posted = append(*posted, ch)
+			mu.Unlock()
+			if code, ok := fail[ch]; ok {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": code})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channel": ch, "ts": "1700000000.000100"})
+		case "/chat.getPermalink":
+			ch := r.URL.Query().Get("channel")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true, "permalink": "https://example.slack.com/archives/" + ch + "/p1700000000000100",
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestRunSend_Broadcast(t *testing.T) {
+	var posted []string
+	server := broadcastServer(t, nil, &posted)
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{to: []string{"C0000000111", "C0000000222", "U0000000333", "C0000000111"}, rate: 1000, concurrency: 4}
+
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runSend("", "Maintenance at 17:00", opts, c))
+	})
+
+	assert.ElementsMatch(t, []string{"C0000000111", "C0000000222", "D0000000333"}, posted)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 5) // header, separator, three rows in input order
+	assert.Contains(t, lines[2], "C0000000111")
+	assert.Contains(t, lines[3], "C0000000222")
+	assert.Contains(t, lines[4], "U0000000333")
+	assert.Contains(t, lines[4], "https://example.slack.com/archives/D0000000333/p1700000000000100")
+}
+
+func TestRunSend_BroadcastJournalsEachCopy(t *testing.T) {
+	log := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	client.SetJournal(log)
+	defer client.SetJournal(nil)
+
+	var posted []string
+	server := broadcastServer(t, map[string]string{"C0000000222": "channel_not_found"}, &posted)
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{to: []string{"C0000000111", "C0000000222", "U0000000333"}, rate: 1000, concurrency: 4}
+	captureTextOutput(t, func() {
+		_ = runSend("", "Maintenance at 17:00", opts, c)
+	})
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	channels := make([]string, 0, len(entries))
+	for _, e := range entries {
+		assert.Equal(t, journal.ActionSend, e.Action)
+		assert.Equal(t, "Maintenance at 17:00", e.Text)
+		channels = append(channels, e.Channel)
+	}
+	assert.ElementsMatch(t, []string{"C0000000111", "D0000000333"}, channels, "failed sends are not journaled")
+}
+
+func TestRunSend_BroadcastPartialFailure(t *testing.T) {
+	var posted []string
+	server := broadcastServer(t, map[string]string{"C0000000222": "not_in_channel"}, &posted)
+	defer server.Close()
+
+	dir := writeTemplateFiles(t, map[string]string{"teams.txt": "C0000000111\n\nC0000000222\n"})
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{toFile: filepath.Join(dir, "teams.txt"), rate: 1000, concurrency: 1}
+
+	var err error
+	out := captureTextOutput(t, func() {
+		err = runSend("", "hello", opts, c)
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 2 destinations failed")
+	assert.Contains(t, err.Error(), "--to C0000000222")
+	assert.NotContains(t, err.Error(), "--to C0000000111")
+	assert.Contains(t, out, "failed: slack API error: not_in_channel")
+}
+
+func TestRunSend_BroadcastRetriesRateLimit(t *testing.T) {
+	defer func(d time.Duration) { client.RetryBackoff = d }(client.RetryBackoff)
+	client.RetryBackoff = time.Millisecond
+
+	var posts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			posts++
+			if posts == 1 {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "ratelimited"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1700000000.000100"})
+		case "/chat.getPermalink":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "permalink": "https://x"})
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{to: []string{"C0000000111"}, rate: 1000, concurrency: 1}
+	captureTextOutput(t, func() {
+		require.NoError(t, runSend("", "hello", opts, c))
+	})
+	assert.Equal(t, 2, posts)
+}
+
+func TestRunSend_BroadcastResolvesBeforeSending(t *testing.T) {
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "channels": []interface{}{}})
+		case "/chat.postMessage":
+			posted = append(posted, "x")
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{to: []string{"C0000000111", "no-such-channel"}, rate: 1000, concurrency: 1}
+	err := runSend("", "hello", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not resolve 1 destination(s); nothing was sent")
+	assert.Contains(t, err.Error(), "no-such-channel")
+	assert.Empty(t, posted)
+}
+
+func TestRunSend_BroadcastConflicts(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	tests := []struct {
+		name    string
+		channel string
+		opts    *sendOptions
+		want    string
+	}{
+		{"with channel", "C0000000111", &sendOptions{to: []string{"C0000000222"}}, "cannot be combined with a channel"},
+		{"with thread", "", &sendOptions{to: []string{"C0000000222"}, threadTS: "1234567890.123456"}, "--thread cannot be used"},
+		{"with file", "", &sendOptions{to: []string{"C0000000222"}, files: []string{"a.txt"}}, "--file cannot be used"},
+		{"empty list", "", &sendOptions{to: []string{" "}, concurrency: 1, rate: 1}, "no destinations"},
+		{"zero concurrency", "", &sendOptions{to: []string{"C0000000222"}, rate: 1}, "--concurrency must be at least 1"},
+		{"zero rate", "", &sendOptions{to: []string{"C0000000222"}, concurrency: 1}, "--rate must be greater than 0"},
+		{"negative rate", "", &sendOptions{to: []string{"C0000000222"}, concurrency: 1, rate: -1}, "--rate must be greater than 0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSend(tt.channel, "hello", tt.opts, c)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+// tailServer fakes conversations.history/replies over a message list that
+// grows as polls happen. step is called with the 1-based history call
+// number before the response is built; returning true fails that call with
+// a transient HTTP error.
+type tailServer struct {
+	mu       sync.Mutex
+	messages []map[string]interface{}
+	replies  map[string][]map[string]interface{}
+	calls    int
+	step     func(call int, s *tailServer) bool
+}
+
+func (s *tailServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		q := r.URL.Query()
+		oldest := q.Get("oldest")
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		switch r.URL.Path {
+		case "/conversations.history":
+			s.calls++
+			if s.step != nil && s.step(s.calls, s) {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			var out []map[string]interface{}
+			for i := len(s.messages) - 1; i >= 0 && len(out) < limit; i-- {
+				if client.CompareTS(s.messages[i]["ts"].(string), oldest) > 0 {
+					out = append(out, s.messages[i])
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": out})
+		case "/conversations.replies":
+			out := []map[string]interface{}{{"ts": q.Get("ts"), "user": "U1", "text": "parent"}}
+			for _, m := range s.replies[q.Get("ts")] {
+				if client.CompareTS(m["ts"].(string), oldest) > 0 {
+					out = append(out, m)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": out})
+		case "/users.info":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "user_not_found"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}
+}
+
+func tailMsg(ts, text string) map[string]interface{} {
+	return map[string]interface{}{"ts": ts, "user": "U1", "text": text}
+}
+
+func TestRunTail_LastMessagesOldestFirst(t *testing.T) {
+	s := &tailServer{messages: []map[string]interface{}{
+		tailMsg("1700000001.000000", "one"),
+		tailMsg("1700000002.000000", "two"),
+		tailMsg("1700000003.000000", "three"),
+	}}
+	server := httptest.NewServer(s.handler(t))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runTail(context.Background(), "C0000000001", &tailOptions{limit: 2}, c))
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "two")
+	assert.Contains(t, lines[1], "three")
+}
+
+func TestRunTail_FollowSurvivesTransientErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &tailServer{messages: []map[string]interface{}{tailMsg("1700000001.000000", "one")}}
+	s.step = func(call int, s *tailServer) bool {
+		switch call {
+		case 2:
+			s.messages = append(s.messages, tailMsg("1700000002.000000", "two"))
+			return true // "two" must still arrive, exactly once
+		case 4:
+			s.messages = append(s.messages, tailMsg("1700000003.000000", "three"))
+		case 6:
+			cancel()
+		}
+		return false
+	}
+	server := httptest.NewServer(s.handler(t))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{limit: 5, follow: true, interval: time.Millisecond, maxInterval: 2 * time.Millisecond}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runTail(ctx, "C0000000001", opts, c))
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	assert.Contains(t, lines[0], "one")
+	assert.Contains(t, lines[1], "two")
+	assert.Contains(t, lines[2], "three")
+}
+
+func TestRunTail_FollowReplies(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parent := tailMsg("1700000002.000000", "parent")
+	s := &tailServer{
+		messages: []map[string]interface{}{tailMsg("1700000001.000000", "older"), parent},
+		replies:  map[string][]map[string]interface{}{},
+	}
+	s.step = func(call int, s *tailServer) bool {
+		switch call {
+		case 2:
+			reply := map[string]interface{}{"ts": "1700000003.000000", "thread_ts": "1700000002.000000", "user": "U1", "text": "a reply"}
+			s.replies["1700000002.000000"] = append(s.replies["1700000002.000000"], reply)
+			parent["reply_count"] = 1
+			parent["latest_reply"] = "1700000003.000000"
+		case 4:
+			cancel()
+		}
+		return false
+	}
+	server := httptest.NewServer(s.handler(t))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{limit: 1, follow: true, replies: true, interval: time.Millisecond, maxInterval: time.Millisecond}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runTail(ctx, "C0000000001", opts, c))
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "parent")
+	assert.True(t, strings.HasPrefix(lines[1], "↳ ["), lines[1])
+	assert.Contains(t, lines[1], "a reply")
+}
+
+func TestRunTail_FollowRepliesPagesForward(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var oldests []string
+	s := &tailServer{
+		messages: []map[string]interface{}{tailMsg("1700000001.000000", "parent")},
+		replies:  map[string][]map[string]interface{}{},
+	}
+	s.step = func(call int, s *tailServer) bool {
+		switch {
+		case call == 2:
+			// More new messages than one poll checks threads for
+			for i := 0; i < 2*tailThreadChecks; i++ {
+				s.messages = append(s.messages, tailMsg(fmt.Sprintf("17000000%02d.000000", 10+i), "new"))
+			}
+		case call == 3:
+			s.replies["1700000001.000000"] = []map[string]interface{}{
+				{"ts": "1700000099.000000", "thread_ts": "1700000001.000000", "user": "U1", "text": "late reply"},
+			}
+		case call == 8:
+			cancel()
+		}
+		return false
+	}
+	handler := s.handler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/conversations.history" && r.URL.Query().Get("oldest") != "" {
+			oldests = append(oldests, r.URL.Query().Get("oldest"))
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{limit: 1, follow: true, replies: true, interval: time.Millisecond, maxInterval: time.Millisecond}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runTail(ctx, "C0000000001", opts, c))
+	})
+
+	assert.Equal(t, 1, strings.Count(out, "late reply"), "the first thread is still followed")
+	assert.Equal(t, 2*tailThreadChecks, strings.Count(out, "new"))
+	require.NotEmpty(t, oldests)
+	assert.Equal(t, "1700000001.000000", oldests[0])
+	for _, o := range oldests[1:] {
+		assert.Equal(t, fmt.Sprintf("17000000%02d.000000", 10+2*tailThreadChecks-1), o, "polls start at the newest message seen")
+	}
+}
+
+func TestRunTail_DeletedThreadIsDropped(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := &tailServer{
+		messages: []map[string]interface{}{tailMsg("1700000001.000000", "deleted parent"), tailMsg("1700000002.000000", "parent")},
+		replies:  map[string][]map[string]interface{}{},
+	}
+	s.step = func(call int, s *tailServer) bool {
+		switch call {
+		case 3:
+			s.replies["1700000002.000000"] = []map[string]interface{}{
+				{"ts": "1700000003.000000", "thread_ts": "1700000002.000000", "user": "U1", "text": "a reply"},
+			}
+		case 6:
+			cancel()
+		}
+		return false
+	}
+	goneChecks := 0
+	handler := s.handler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/conversations.replies" && r.URL.Query().Get("ts") == "1700000001.000000" {
+			goneChecks++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "thread_not_found"})
+			return
+		}
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{limit: 2, follow: true, replies: true, interval: time.Millisecond, maxInterval: time.Millisecond}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runTail(ctx, "C0000000001", opts, c))
+	})
+
+	assert.Contains(t, out, "a reply", "the other thread is still followed")
+	assert.Equal(t, 1, goneChecks, "a deleted thread is dropped after one check")
+}
+
+func TestRunTail_FatalErrorStops(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": []interface{}{}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "not_in_channel"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &tailOptions{follow: true, interval: time.Millisecond, maxInterval: time.Millisecond}
+	err := runTail(context.Background(), "C0000000001", opts, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not_in_channel")
+}
+
+func TestRunHistory_AllChronologicalWithReplies(t *testing.T) {
+	var gotOldest, gotLimit string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			gotOldest = r.URL.Query().Get("oldest")
+			gotLimit = r.URL.Query().Get("limit")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000003.000000", "user": "U001", "text": "resolved"},
+					{"ts": "1700000002.000000", "user": "U001", "text": "mitigating", "reply_count": 1},
+					{"ts": "1700000001.000000", "user": "U001", "text": "paged"},
+				},
+			})
+		case "/conversations.replies":
+			assert.Equal(t, "1700000002.000000", r.URL.Query().Get("ts"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000002.000000", "user": "U001", "text": "mitigating"},
+					{"ts": "1700000002.500000", "thread_ts": "1700000002.000000", "user": "U001", "text": "rolled back"},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &historyOptions{limit: 20, all: true, since: "1699999999", withReplies: true}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runHistory("C123", opts, c))
+	})
+
+	assert.Equal(t, "1699999999.000000", gotOldest)
+	assert.Equal(t, "200", gotLimit)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "paged")
+	assert.Contains(t, lines[1], "mitigating")
+	assert.True(t, strings.HasPrefix(lines[2], "  ↳ ["), lines[2])
+	assert.Contains(t, lines[2], "rolled back")
+	assert.Contains(t, lines[3], "resolved")
+}
+
+func TestHistoryRange(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	oldest, latest, err := historyRange(&historyOptions{since: "1d", until: "2026-10-18"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "1792238400.000000", oldest)
+	assert.Equal(t, "1792281600.000000", latest)
+
+	_, _, err = historyRange(&historyOptions{since: "1d", oldest: "1.0"}, now)
+	assert.ErrorContains(t, err, "--since and --oldest")
+
+	_, _, err = historyRange(&historyOptions{until: "1d", latest: "1.0"}, now)
+	assert.ErrorContains(t, err, "--until and --latest")
+
+	_, _, err = historyRange(&historyOptions{since: "soon"}, now)
+	assert.ErrorContains(t, err, "--since: invalid time")
+
+	_, _, err = historyRange(&historyOptions{since: "1d", until: "2d"}, now)
+	assert.ErrorContains(t, err, "start of the range")
+}
+
+func TestRunSend_IdentityAndDeliveryOptions(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{
+		threadTS:       "1234567890.000001",
+		replyBroadcast: true,
+		username:       "Disk Monitor",
+		iconEmoji:      "floppy_disk",
+		noUnfurlMedia:  true,
+	}
+
+	err := runSend("C123456789", "Disk 91% full", opts, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, true, receivedBody["reply_broadcast"])
+	assert.Equal(t, "Disk Monitor", receivedBody["username"])
+	assert.Equal(t, ":floppy_disk:", receivedBody["icon_emoji"])
+	assert.Equal(t, true, receivedBody["unfurl_links"])
+	assert.Equal(t, false, receivedBody["unfurl_media"])
+	assert.NotNil(t, receivedBody["blocks"], "identity options keep the default block styling")
+}
+
+func TestRunSend_ParseAndMrkdwnSendPlainText(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{parse: "none", noMrkdwn: true}
+
+	err := runSend("C123456789", "*not bold*", opts, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "none", receivedBody["parse"])
+	assert.Equal(t, false, receivedBody["mrkdwn"])
+	assert.Equal(t, "*not bold*", receivedBody["text"])
+	assert.Nil(t, receivedBody["blocks"])
+}
+
+func TestRunSend_DeliveryOptionConflicts(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	tests := []struct {
+		name string
+		opts *sendOptions
+		want string
+	}{
+		{"broadcast without thread", &sendOptions{replyBroadcast: true}, "--broadcast requires --thread"},
+		{"two icons", &sendOptions{iconEmoji: ":x:", iconURL: "https://e.com/x.png"}, "--icon-emoji and --icon-url cannot be used together"},
+		{"bad parse", &sendOptions{parse: "client"}, `invalid --parse "client"`},
+		{"file", &sendOptions{username: "bot", files: []string{"a.txt"}}, "cannot be used with --file"},
+		{"blocks", &sendOptions{noMrkdwn: true, blocksJSON: "[]"}, "--parse and --mrkdwn=false cannot be combined with --blocks"},
+		{"markdown", &sendOptions{parse: "full", markdown: true}, "--markdown cannot be combined with --parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSend("C123", "x", tt.opts, c)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRunSend_Metadata(t *testing.T) {
+	var receivedBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&receivedBody)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1234567890.123456"})
+	}))
+	defer server.Close()
+
+	payload := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(payload, []byte(`{"service":"api","version":"1.4.2"}`), 0o644))
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &sendOptions{metadataType: "deploy_started", metadataFile: payload}
+	require.NoError(t, runSend("C123456789", "Deploying api", opts, c))
+
+	assert.Equal(t, map[string]interface{}{
+		"event_type":    "deploy_started",
+		"event_payload": map[string]interface{}{"service": "api", "version": "1.4.2"},
+	}, receivedBody["metadata"])
+}
+
+func TestRunSend_MetadataErrors(t *testing.T) {
+	c := client.NewWithConfig("http://localhost", "test-token", nil)
+	notObject := filepath.Join(t.TempDir(), "list.json")
+	require.NoError(t, os.WriteFile(notObject, []byte(`[1,2]`), 0o644))
+
+	tests := []struct {
+		name string
+		opts *sendOptions
+		want string
+	}{
+		{"file without type", &sendOptions{metadataFile: notObject}, "--metadata-file requires --metadata-type"},
+		{"not an object", &sendOptions{metadataType: "x", metadataFile: notObject}, "must contain a JSON object"},
+		{"with upload", &sendOptions{metadataType: "x", files: []string{"a.txt"}}, "cannot be used with --file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runSend("C123", "x", tt.opts, c)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRunHistory_ShowsAndFiltersMetadata(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			assert.Equal(t, "true", r.URL.Query().Get("include_all_metadata"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000002.000000", "user": "U001", "text": "Deploying api",
+						"metadata": map[string]interface{}{
+							"event_type":    "deploy_started",
+							"event_payload": map[string]interface{}{"service": "api"},
+						}},
+					{"ts": "1700000001.000000", "user": "U001", "text": "unrelated"},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runHistory("C123", &historyOptions{limit: 20}, c))
+	})
+	assert.Contains(t, out, "unrelated")
+	assert.Contains(t, out, "\t[metadata] deploy_started {\"service\":\"api\"}\n")
+
+	out = captureTextOutput(t, func() {
+		require.NoError(t, runHistory("C123", &historyOptions{limit: 20, metadataType: "deploy_started"}, c))
+	})
+	assert.NotContains(t, out, "unrelated")
+	assert.Contains(t, out, "Deploying api")
+}
+
+func TestSplitMessageArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantChannel string
+		wantTS      string
+		wantRest    []string
+	}{
+		{"two-argument form", []string{"general", "1234567890.123456", "thumbsup"}, "general", "1234567890.123456", []string{"thumbsup"}},
+		{"ref", []string{"C1234567890/1234567890.123456", "thumbsup"}, "C1234567890", "1234567890.123456", []string{"thumbsup"}},
+		{"p-form ref", []string{"C1234567890/p1234567890123456"}, "C1234567890", "1234567890.123456", []string{}},
+		{"permalink", []string{"https://example.slack.com/archives/C1234567890/p1234567890123456?thread_ts=1.2"}, "C1234567890", "1234567890.123456", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, ts, rest, err := splitMessageArgs(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChannel, channel)
+			assert.Equal(t, tt.wantTS, ts)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+
+	_, _, _, err := splitMessageArgs([]string{"C1234567890/not-a-ts"})
+	assert.Error(t, err)
+}
+
+func TestMessageArgs(t *testing.T) {
+	args := messageArgs(1, 1)
+	cmd := newReactCmd()
+	assert.NoError(t, args(cmd, []string{"C1234567890/1234567890.123456", "thumbsup"}))
+	assert.NoError(t, args(cmd, []string{"C1234567890", "1234567890.123456", "thumbsup"}))
+	assert.Error(t, args(cmd, []string{"C1234567890/1234567890.123456"}))
+	assert.Error(t, args(cmd, []string{"C1234567890", "1234567890.123456"}))
+	assert.Error(t, args(cmd, []string{"C1234567890/1234567890.123456", "thumbsup", "extra"}))
+}
+
+func TestReactCmd_AcceptsRef(t *testing.T) {
+	var gotChannel, gotTS, gotName string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		gotChannel, gotTS, gotName = body["channel"], body["timestamp"], body["name"]
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+	}))
+	defer server.Close()
+
+	channel, ts, rest, err := splitMessageArgs([]string{"https://example.slack.com/archives/C1234567890/p1234567890123456", ":tada:"})
+	require.NoError(t, err)
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	captureTextOutput(t, func() {
+		require.NoError(t, runReact(channel, ts, rest[0], &reactOptions{}, c))
+	})
+	assert.Equal(t, "C1234567890", gotChannel)
+	assert.Equal(t, "1234567890.123456", gotTS)
+	assert.Equal(t, "tada", gotName)
+}
+
+// purgeServer serves a fixed history and records chat.delete calls. fail
+// maps a ts to the error its deletion returns.
+func purgeServer(t *testing.T, fail map[string]string, deleted *[]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000004.000000", "bot_id": "B001", "text": "[test] four", "reply_count": 1},
+					{"ts": "1700000003.000000", "user": "U001", "text": "[test] human"},
+					{"ts": "1700000002.000000", "bot_id": "B001", "text": "real alert"},
+					{"ts": "1700000001.000000", "bot_id": "B001", "text": "[test] one"},
+				},
+			})
+		case "/conversations.replies":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000004.000000", "bot_id": "B001", "text": "[test] four"},
+					{"ts": "1700000005.000000", "bot_id": "B001", "text": "[test] reply", "thread_ts": "1700000004.000000"},
+				},
+			})
+		case "/chat.delete":
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if code, ok := fail[body["ts"]]; ok {
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": code})
+				return
+			}
+			*deleted = append(*deleted, body["ts"])
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestJournal_DeleteAndPurgeRecorded(t *testing.T) {
+	log := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	client.SetJournal(log)
+	defer client.SetJournal(nil)
+
+	var deleted []string
+	server := purgeServer(t, nil, &deleted)
+	defer server.Close()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	captureTextOutput(t, func() {
+		require.NoError(t, runDelete("C123", "1700000004.000000", &deleteOptions{force: true}, c))
+		require.NoError(t, runPurge("C123", &purgeOptions{fromBot: "B001", match: `^\[test\]`, force: true, rate: 1000}, c))
+	})
+	assert.Len(t, deleted, 3)
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3, "every purge delete is journaled")
+	for _, e := range entries {
+		assert.Equal(t, journal.ActionDelete, e.Action)
+		assert.True(t, e.HasPrevious)
+	}
+	assert.Equal(t, "[test] four", entries[0].Previous)
+	assert.Equal(t, "[test] one", entries[1].Previous, "purge records the text it deleted")
+	assert.Equal(t, "1700000004.000000", entries[2].TS)
+}
+
+func TestRunPurge_DryRun(t *testing.T) {
+	var deleted []string
+	server := purgeServer(t, nil, &deleted)
+	defer server.Close()
+
+	var calls bytes.Buffer
+	client.SetDryRun(&calls)
+	defer client.SetDryRun(nil)
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &purgeOptions{fromBot: "B001", match: `^\[test\]`, rate: 1000}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runPurge("C123", opts, c))
+	})
+
+	assert.Empty(t, deleted)
+	assert.Contains(t, out, "1700000001.000000")
+	assert.Contains(t, out, "1700000004.000000")
+	assert.NotContains(t, out, "1700000002.000000", "text does not match")
+	assert.NotContains(t, out, "1700000003.000000", "posted by a user")
+	assert.Contains(t, out, "Dry run: 2 messages would be deleted")
+	assert.Empty(t, calls.String(), "no delete calls are printed")
+}
+
+func TestRunPurge_DeletesWithRepliesAndReportsFailures(t *testing.T) {
+	var deleted []string
+	server := purgeServer(t, map[string]string{"1700000004.000000": "cant_delete_message"}, &deleted)
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &purgeOptions{fromBot: "B001", match: `^\[test\]`, replies: true, rate: 1000, stdin: strings.NewReader("y\n")}
+	var err error
+	out := captureTextOutput(t, func() {
+		err = runPurge("C123", opts, c)
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 messages could not be deleted")
+	assert.Equal(t, []string{"1700000001.000000", "1700000005.000000"}, deleted)
+	assert.Contains(t, out, "Deleted 2 of 3 messages")
+	assert.Contains(t, out, "cant_delete_message")
+}
+
+func TestRunPurge_Cancelled(t *testing.T) {
+	for name, answer := range map[string]string{"no": "n\n", "no input": ""} {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			server := purgeServer(t, nil, &deleted)
+			defer server.Close()
+
+			c := client.NewWithConfig(server.URL, "test-token", nil)
+			opts := &purgeOptions{fromBot: "B001", rate: 1000, stdin: strings.NewReader(answer)}
+			out := captureTextOutput(t, func() {
+				require.NoError(t, runPurge("C123", opts, c))
+			})
+
+			assert.Empty(t, deleted)
+			assert.Contains(t, out, "Cancelled.")
+		})
+	}
+}
+
+func TestRunPurge_RequiresFilter(t *testing.T) {
+	err := runPurge("C123", &purgeOptions{rate: 1}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one of --from-bot")
+
+	err = runPurge("C123", &purgeOptions{match: "(", rate: 1}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --match")
+}
+
+func TestRunReactions_ListsUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reactions.get":
+			assert.Equal(t, "1700000000.000001", r.URL.Query().Get("timestamp"))
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"message": map[string]interface{}{
+					"ts": "1700000000.000001",
+					"reactions": []map[string]interface{}{
+						{"name": "eyes", "count": 1, "users": []string{"U001"}},
+						{"name": "thumbsup", "count": 2, "users": []string{"U001", "U002"}},
+					},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runReactions("C123", "1700000000.000001", &reactionsOptions{}, c))
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[2], ":thumbsup:")
+	assert.Contains(t, lines[2], "2")
+	assert.Contains(t, lines[3], ":eyes:")
+	assert.NotContains(t, out, "U001", "user IDs are resolved to names")
+}
+
+func TestRunReactions_TallyHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conversations.history":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"ts": "1700000002.000000", "reactions": []map[string]interface{}{
+						{"name": "white_check_mark", "count": 30, "users": []string{"U001", "U002"}},
+					}},
+					{"ts": "1700000001.000000", "reactions": []map[string]interface{}{
+						{"name": "white_check_mark", "count": 1, "users": []string{"U001"}},
+						{"name": "x", "count": 1, "users": []string{"U003"}},
+					}},
+					{"ts": "1700000000.000000"},
+				},
+			})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runReactions("C123", "", &reactionsOptions{tally: true, since: "1699999999"}, c))
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 5)
+	assert.Contains(t, lines[2], ":white_check_mark:")
+	assert.Contains(t, lines[2], "31")
+	assert.Contains(t, lines[2], "and 28 more")
+	assert.Contains(t, lines[3], ":x:")
+	assert.Equal(t, "3 messages counted", lines[4])
+}
+
+func TestRunReactions_Validation(t *testing.T) {
+	err := runReactions("C123", "", &reactionsOptions{}, nil)
+	assert.ErrorContains(t, err, "requires --tally")
+
+	err = runReactions("C123", "1700000000.000001", &reactionsOptions{tally: true, since: "1d"}, nil)
+	assert.ErrorContains(t, err, "--since and --until only apply")
+}
+
+// waitServer answers reactions.get with the reactions for the n-th poll
+// (the last entry repeats) and records chat.postMessage calls.
+func waitServer(t *testing.T, polls [][]map[string]interface{}, posted *[]map[string]interface{}) *client.Client {
+	t.Helper()
+	var mu sync.Mutex
+	n := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/reactions.get":
+			reactions := polls[min(n, len(polls)-1)]
+			n++
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":      true,
+				"message": map[string]interface{}{"ts": r.URL.Query().Get("timestamp"), "reactions": reactions},
+			})
+		case "/usergroups.list":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":         true,
+				"usergroups": []map[string]interface{}{{"id": "S001", "handle": "releasemgrs", "users": []string{"U002"}}},
+			})
+		case "/chat.postMessage":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*posted = append(*posted, body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1700000000.000100"})
+		case "/users.info":
+			mockUserInfoHandler(w, r)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func TestRunWait_ApprovedByGroupMember(t *testing.T) {
+	c := waitServer(t, [][]map[string]interface{}{
+		{},
+		{{"name": "white_check_mark", "count": 1, "users": []string{"U001"}}},
+		{{"name": "white_check_mark::skin-tone-3", "count": 1, "users": []string{"U002"}}},
+	}, nil)
+
+	opts := &waitOptions{reaction: "white_check_mark", reject: "x", from: []string{"@releasemgrs"}, timeout: time.Second, interval: time.Millisecond}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runWait(context.Background(), "C123", "1700000000.000001", opts, c))
+	})
+	assert.Equal(t, "Approved by bob\n", out, "alice is not in the group, so only bob's reaction counts")
+}
+
+func TestRunWait_RejectedAndTimeout(t *testing.T) {
+	c := waitServer(t, [][]map[string]interface{}{
+		{{"name": "white_check_mark", "count": 1, "users": []string{"U001"}}, {"name": "x", "count": 1, "users": []string{"U002"}}},
+	}, nil)
+	opts := &waitOptions{reaction: "white_check_mark", reject: "x", timeout: time.Second, interval: time.Millisecond}
+
+	var err error
+	out := captureTextOutput(t, func() {
+		err = runWait(context.Background(), "C123", "1700000000.000001", opts, c)
+	})
+	var werr *waitError
+	require.ErrorAs(t, err, &werr)
+	assert.Equal(t, waitRejectedCode, werr.ExitCode())
+	assert.Equal(t, "Rejected by bob\n", out)
+
+	c = waitServer(t, [][]map[string]interface{}{{}}, nil)
+	opts.timeout = 20 * time.Millisecond
+	out = captureTextOutput(t, func() {
+		err = runWait(context.Background(), "C123", "1700000000.000001", opts, c)
+	})
+	require.ErrorAs(t, err, &werr)
+	assert.Equal(t, waitTimeoutCode, werr.ExitCode())
+	assert.Equal(t, "Timed out after 20ms\n", out)
+}
+
+func TestReportedOutcome_SilencesOnlyWaitOutcomes(t *testing.T) {
+	cmd := &cobra.Command{}
+	err := reportedOutcome(cmd, fmt.Errorf("channel_not_found"))
+	assert.Error(t, err)
+	assert.False(t, cmd.SilenceErrors, "other errors are printed by the root command")
+	assert.False(t, cmd.SilenceUsage)
+
+	werr := &waitError{code: waitTimeoutCode, msg: "timed out"}
+	assert.Same(t, werr, reportedOutcome(cmd, werr))
+	assert.True(t, cmd.SilenceErrors)
+	assert.True(t, cmd.SilenceUsage)
+}
+
+func TestRunWait_PostsRequest(t *testing.T) {
+	var posted []map[string]interface{}
+	c := waitServer(t, [][]map[string]interface{}{
+		{{"name": "white_check_mark", "count": 1, "users": []string{"U001"}}},
+	}, &posted)
+
+	opts := &waitOptions{reaction: ":white_check_mark:", post: "Deploy?", timeout: time.Second, interval: time.Millisecond}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runWait(context.Background(), "C123", "", opts, c))
+	})
+	require.Len(t, posted, 1)
+	assert.Equal(t, "Deploy?", posted[0]["text"])
+	assert.Contains(t, out, "Message sent (ts: 1700000000.000100)")
+	assert.Contains(t, out, "Approved by alice")
+}
+
+func TestRunWait_DryRunPostDoesNotPoll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s under --dry-run", r.URL.Path)
+	}))
+	defer server.Close()
+
+	var calls bytes.Buffer
+	client.SetDryRun(&calls)
+	defer client.SetDryRun(nil)
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	opts := &waitOptions{reaction: "white_check_mark", post: "Deploy?", timeout: time.Second, interval: time.Millisecond}
+	captureTextOutput(t, func() {
+		require.NoError(t, runWait(context.Background(), "C123", "", opts, c))
+	})
+	assert.Contains(t, calls.String(), "chat.postMessage")
+}
+
+func TestRunWait_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts waitOptions
+		want string
+	}{
+		{"same reactions", waitOptions{reaction: "ok", reject: ":ok:", timeout: time.Second, interval: time.Second}, "must differ"},
+		{"no timeout", waitOptions{reaction: "ok", interval: time.Second}, "must be positive"},
+		{"thread without post", waitOptions{reaction: "ok", threadTS: "1.2", timeout: time.Second, interval: time.Second}, "--thread requires --post"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runWait(context.Background(), "C123", "1700000000.000001", &tt.opts, nil)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	fits := func(s string) bool { return len(s) <= 30 }
+
+	t.Run("fits unchanged", func(t *testing.T) {
+		assert.Equal(t, []string{"short\n\n\ntext"}, splitText("short\n\n\ntext", fits))
+	})
+
+	t.Run("groups paragraphs", func(t *testing.T) {
+		got := splitText("aaaa aaaa\n\nbbbb bbbb\n\ncccc cccc cccc cccc", fits)
+		assert.Equal(t, []string{"aaaa aaaa\n\nbbbb bbbb", "cccc cccc cccc cccc"}, got)
+	})
+
+	t.Run("keeps a fence with blank lines together", func(t *testing.T) {
+		got := splitText("intro text here\n\n```\nx\n\ny\n```\n\nend", fits)
+		assert.Equal(t, []string{"intro text here\n\n```\nx\n\ny\n```", "end"}, got)
+	})
+
+	t.Run("re-fences an oversized block", func(t *testing.T) {
+		got := splitText("```go\nline one\nline two\nline three\n```", fits)
+		assert.Equal(t, []string{"```go\nline one\nline two\n```", "```go\nline three\n```"}, got)
+	})
+}
+
+// splitServer records chat.postMessage bodies, answering with sequential
+// timestamps, and serves permalinks.
+func splitServer(t *testing.T, posted *[]map[string]interface{}) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*posted = append(*posted, body)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": "1700000000.00000" + strconv.Itoa(len(*posted))})
+		case "/chat.getPermalink":
+			ts := strings.ReplaceAll(r.URL.Query().Get("message_ts"), ".", "")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "permalink": "https://example.slack.com/archives/C123/p" + ts})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func TestRunSend_SplitsLongTextIntoThread(t *testing.T) {
+	var posted []map[string]interface{}
+	c := splitServer(t, &posted)
+
+	para := strings.Repeat("word ", 5000) // 25,000 characters
+	text := para + "\n\n" + para
+	metadata := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(metadata, []byte(`{"run": 1}`), 0o600))
+
+	opts := &sendOptions{simple: true, metadataType: "report", metadataFile: metadata}
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runSend("C123", text, opts, c))
+	})
+
+	require.Len(t, posted, 2)
+	assert.Equal(t, strings.TrimSpace(para), strings.TrimSpace(posted[0]["text"].(string)))
+	assert.Nil(t, posted[0]["thread_ts"])
+	assert.NotNil(t, posted[0]["metadata"])
+	assert.Equal(t, "1700000000.000001", posted[1]["thread_ts"], "later parts reply to the first")
+	assert.Nil(t, posted[1]["metadata"], "metadata is only attached once")
+
+	assert.Contains(t, out, "Message split into 2 parts")
+	assert.Contains(t, out, "https://example.slack.com/archives/C123/p1700000000000001")
+	assert.Contains(t, out, "https://example.slack.com/archives/C123/p1700000000000002")
+	assert.Equal(t, "1700000000.000001", opts.sent.TS)
+}
+
+func TestRunSend_DryRunSkipsPermalinks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to %s under --dry-run", r.URL.Path)
+	}))
+	defer server.Close()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	var calls bytes.Buffer
+	client.SetDryRun(&calls)
+	defer client.SetDryRun(nil)
+
+	para := strings.Repeat("word ", 5000)
+	captureTextOutput(t, func() {
+		require.NoError(t, runSend("C123", para+"\n\n"+para, &sendOptions{simple: true}, c))
+		require.NoError(t, runSend("C123", "hello", &sendOptions{simple: true, permalink: true}, c))
+	})
+	assert.Equal(t, 3, strings.Count(calls.String(), "chat.postMessage"))
+}
+
+func TestRunSend_SplitModeChannelAndBlocks(t *testing.T) {
+	var posted []map[string]interface{}
+	c := splitServer(t, &posted)
+
+	blocks := make([]string, client.MaxBlocksPerMessage+5)
+	for i := range blocks {
+		blocks[i] = `{"type":"divider"}`
+	}
+	opts := &sendOptions{blocksJSON: "[" + strings.Join(blocks, ",") + "]", splitMode: splitModeChannel}
+	captureTextOutput(t, func() {
+		require.NoError(t, runSend("C123", "fallback", opts, c))
+	})
+
+	require.Len(t, posted, 2)
+	assert.Len(t, posted[0]["blocks"], client.MaxBlocksPerMessage)
+	assert.Len(t, posted[1]["blocks"], 5)
+	assert.Equal(t, "fallback", posted[0]["text"])
+	assert.Nil(t, posted[1]["thread_ts"], "channel mode posts every part at the top level")
+
+	err := runSend("C123", "x", &sendOptions{splitMode: "dm"}, c)
+	assert.ErrorContains(t, err, "invalid --split-mode")
+}
+
+func TestRunSend_SplitsMarkdownByBlockCount(t *testing.T) {
+	var posted []map[string]interface{}
+	c := splitServer(t, &posted)
+
+	md := strings.Repeat("# heading\n\n", client.MaxBlocksPerMessage+1)
+	captureTextOutput(t, func() {
+		require.NoError(t, runSend("C123", md, &sendOptions{markdown: true}, c))
+	})
+
+	require.Len(t, posted, 2)
+	assert.Len(t, posted[0]["blocks"], client.MaxBlocksPerMessage)
+	assert.Len(t, posted[1]["blocks"], 1)
+}
+
+func TestRunSend_LinkNames(t *testing.T) {
+	var posted map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/users.list":
+			resp["members"] = []map[string]interface{}{{"id": "U111", "name": "alice"}}
+		case "/usergroups.list":
+			resp["usergroups"] = []map[string]interface{}{}
+		case "/conversations.list":
+			resp["channels"] = []map[string]interface{}{{"id": "C222", "name": "deploys"}}
+		case "/chat.postMessage":
+			_ = json.NewDecoder(r.Body).Decode(&posted)
+			resp["ts"] = "1234567890.123456"
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	err := runSend("C123", "@alice @nobody please check #deploys, @here", &sendOptions{simple: true, linkNames: true}, c)
+	require.NoError(t, err)
+	assert.Equal(t, "<@U111> @nobody please check <#C222>, <!here>", posted["text"])
+
+	err = runSend("C123", "", &sendOptions{blocksJSON: `[{"type":"divider"}]`, linkNames: true}, c)
+	assert.ErrorContains(t, err, "--link-names cannot be combined")
+}
+
+// shareServer serves a source thread and records what share posts.
+func shareServer(t *testing.T, posted *[]map[string]interface{}) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/chat.getPermalink":
+			resp["permalink"] = "https://example.slack.com/archives/C111/p1700000000000200"
+		case "/conversations.replies":
+			resp["messages"] = []map[string]interface{}{
+				{"type": "message", "user": "U1", "text": "parent", "ts": "1700000000.000100"},
+				{"type": "message", "user": "U1", "text": "deploy *failed*", "ts": "1700000000.000200",
+					"blocks": []map[string]interface{}{{"type": "section", "block_id": "b1", "text": map[string]interface{}{"type": "mrkdwn", "text": "deploy *failed*"}}}},
+			}
+		case "/users.info":
+			resp["user"] = map[string]interface{}{"id": "U1", "name": "alice"}
+		case "/chat.postMessage":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			*posted = append(*posted, body)
+			resp["ts"] = "1800000000.000001"
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func TestRunShare_PostsPermalink(t *testing.T) {
+	var posted []map[string]interface{}
+	c := shareServer(t, &posted)
+
+	out := captureTextOutput(t, func() {
+		require.NoError(t, runShare("C111", "1700000000.000200", "C222", "FYI", &shareOptions{}, c))
+	})
+
+	require.Len(t, posted, 1)
+	assert.Equal(t, "C222", posted[0]["channel"])
+	assert.Equal(t, "FYI\nhttps://example.slack.com/archives/C111/p1700000000000200", posted[0]["text"])
+	assert.Equal(t, true, posted[0]["unfurl_links"])
+	assert.Nil(t, posted[0]["blocks"])
+	assert.Contains(t, out, "Message shared to C222/1800000000.000001")
+}
+
+func TestRunShare_CopyWithAttribution(t *testing.T) {
+	var posted []map[string]interface{}
+	c := shareServer(t, &posted)
+
+	captureTextOutput(t, func() {
+		require.NoError(t, runShare("C111", "1700000000.000200", "C222", "", &shareOptions{copy: true}, c))
+	})
+
+	require.Len(t, posted, 1)
+	assert.Equal(t, "deploy *failed*", posted[0]["text"])
+	blocks := posted[0]["blocks"].([]interface{})
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "b1", blocks[0].(map[string]interface{})["block_id"], "the original blocks are copied as sent")
+	context, _ := json.Marshal(blocks[1])
+	assert.Contains(t, string(context), "Originally posted by *alice* in \\u003c#C111\\u003e")
+	assert.NotContains(t, string(context), "@U1", "the author is named, not mentioned")
+
+	err := runShare("C111", "1700000000.000300", "C222", "", &shareOptions{copy: true}, c)
+	assert.ErrorContains(t, err, "message 1700000000.000300 not found")
+}
+
+// fakeEditor replaces the editor for one test: it records the file the
+// editor was opened on and writes edit's result over it.
+func fakeEditor(t *testing.T, edit func(opened string) string) *string {
+	t.Helper()
+	var opened string
+	orig := runEditor
+	runEditor = func(path string) error {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		opened = string(data)
+		return os.WriteFile(path, []byte(edit(opened)), 0o600)
+	}
+	t.Cleanup(func() { runEditor = orig })
+	return &opened
+}
+
+func TestEditMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial string
+		edit    func(string) string
+		want    string
+		wantErr string
+	}{
+		{"drops help", "", func(s string) string { return "# Heading\n\nbody\n" + s }, "# Heading\n\nbody", ""},
+		{"keeps draft", "draft", func(s string) string { return "draft, edited" }, "draft, edited", ""},
+		{"empty", "", func(s string) string { return s }, "", "the message is empty"},
+		{"unchanged", "draft", func(s string) string { return s }, "", "the message is unchanged"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeEditor(t, tt.edit)
+			got, err := editMessage(tt.initial, "# help\n")
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRunSend_Edit(t *testing.T) {
+	var posted []map[string]interface{}
+	c := splitServer(t, &posted)
+	opened := fakeEditor(t, func(s string) string { return "Line one\nLine two\n" + s })
+
+	captureTextOutput(t, func() {
+		require.NoError(t, runSend("C123", "draft", &sendOptions{simple: true, edit: true}, c))
+	})
+
+	assert.True(t, strings.HasPrefix(*opened, "draft\n\n"+editorScissors+"\n"), "the text argument is the starting draft")
+	require.Len(t, posted, 1)
+	assert.Equal(t, "Line one\nLine two\ndraft", posted[0]["text"])
+
+	err := runSend("C123", "-", &sendOptions{edit: true}, c)
+	assert.ErrorContains(t, err, "cannot use '-' for text and --edit together")
+}
+
+func TestRunUpdate_Edit(t *testing.T) {
+	var updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/conversations.replies":
+			resp["messages"] = []map[string]interface{}{{"type": "message", "text": "Deploy at 5pm", "ts": "1234567890.123456"}}
+		case "/chat.update":
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+
+	fakeEditor(t, func(s string) string { return strings.Replace(s, "5pm", "6pm", 1) })
+	captureTextOutput(t, func() {
+		require.NoError(t, runUpdate("C123", "1234567890.123456", "", &updateOptions{simple: true, edit: true}, c))
+	})
+	assert.Equal(t, "Deploy at 6pm", updated["text"])
+
+	updated = nil
+	fakeEditor(t, func(s string) string { return s })
+	err := runUpdate("C123", "1234567890.123456", "", &updateOptions{edit: true}, c)
+	assert.ErrorContains(t, err, "aborted: the message is unchanged")
+	assert.Nil(t, updated)
+}
@@ -14,7 +14,7 @@ func newPermalinkCmd() *cobra.Command {
 	opts := &permalinkOptions{}
 
 	return &cobra.Command{
-		Use:   "permalink <channel> <timestamp>",
+		Use:   "permalink {<ref> | <channel> <timestamp>}",
 		Short: "Get a permalink URL to a message",
 		Long: `Get a canonical permalink URL to a specific message.
 
@@ -22,10 +22,17 @@ Wraps Slack's chat.getPermalink, which returns the correct link for
 top-level messages, thread replies, and enterprise-grid workspaces —
 preferred over constructing an archive URL by hand.
 
-  slck messages permalink C1234567890 1234567890.123456`,
-		Args: cobra.ExactArgs(2),
+` + messageRefHelp + `
+
+  slck messages permalink C1234567890 1234567890.123456
+  slck messages permalink C1234567890/1234567890.123456`,
+		Args: messageArgs(0, 0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runPermalink(args[0], args[1], opts, nil)
+			channel, timestamp, _, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return runPermalink(channel, timestamp, opts, nil)
 		},
 	}
 }
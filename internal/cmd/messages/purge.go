@@ -0,0 +1,266 @@
+package messages
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+const (
+	defaultPurgeRate = 1.0 // deletions per second
+
+	// maxPurgeAttempts bounds retries of a deletion that Slack rate-limited.
+	maxPurgeAttempts = 3
+)
+
+type purgeOptions struct {
+	fromBot  string
+	fromUser string
+	match    string
+	before   string
+	after    string
+	replies  bool
+	force    bool
+	rate     float64
+	stdin    io.Reader // For testing
+}
+
+func newPurgeCmd() *cobra.Command {
+	opts := &purgeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "purge <channel>",
+		Short: "Delete every message matching a filter",
+		Long: `Delete every message in a channel that matches all of the given filters.
+
+The whole range is paged and the matching messages are listed first; then
+one confirmation is asked for (skip it with --force) and they are deleted
+oldest first at --rate per second. Messages that cannot be deleted are
+reported individually and the command exits non-zero.
+
+At least one filter is required:
+  --from-bot    bot ID (B…) that posted the messages
+  --from-user   user ID or @handle that posted the messages
+  --match       regular expression matched against the message text
+  --before      only messages before this time
+  --after       only messages after this time
+
+--before and --after take a relative age ("30m", "12h", "7d"), a date
+("2026-10-01"), a date and time, or a Slack timestamp. With --replies, thread
+replies are checked too. Deleting other people's messages needs a user token
+with the right permissions. Under --dry-run the matching messages are listed
+and nothing is deleted.
+
+Examples:
+  slck messages purge alerts --from-bot B0123456789 --before 2026-10-01 --dry-run
+  slck messages purge alerts --from-bot B0123456789 --match '^\[test\]' --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPurge(args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.fromBot, "from-bot", "", "Only messages posted by this bot ID")
+	cmd.Flags().StringVar(&opts.fromUser, "from-user", "", "Only messages posted by this user ID or @handle")
+	cmd.Flags().StringVar(&opts.match, "match", "", "Only messages whose text matches this regular expression")
+	cmd.Flags().StringVar(&opts.before, "before", "", "Only messages before this time (e.g. 2026-10-01, 7d)")
+	cmd.Flags().StringVar(&opts.after, "after", "", "Only messages after this time (e.g. 2026-09-01, 30d)")
+	cmd.Flags().BoolVar(&opts.replies, "replies", false, "Also check thread replies")
+	cmd.Flags().BoolVarP(&opts.force, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().Float64Var(&opts.rate, "rate", defaultPurgeRate, "Maximum deletions per second")
+
+	return cmd
+}
+
+// purgeFilter holds the compiled --from-*/--match filters.
+type purgeFilter struct {
+	botID  string
+	userID string
+	match  *regexp.Regexp
+}
+
+func (f *purgeFilter) matches(m client.Message) bool {
+	if f.botID != "" && m.BotID != f.botID {
+		return false
+	}
+	if f.userID != "" && m.User != f.userID {
+		return false
+	}
+	if f.match != nil && !f.match.MatchString(m.Text) {
+		// Bot messages often carry their content in blocks or attachments
+		body := client.RenderMessage(client.MessageContent{Blocks: m.Blocks, Attachments: m.Attachments}, nil).Body
+		if !f.match.MatchString(body) {
+			return false
+		}
+	}
+	return true
+}
+
+func runPurge(channel string, opts *purgeOptions, c *client.Client) error {
+	if opts.fromBot == "" && opts.fromUser == "" && opts.match == "" && opts.before == "" && opts.after == "" {
+		return fmt.Errorf("at least one of --from-bot, --from-user, --match, --before or --after is required")
+	}
+	if opts.rate <= 0 {
+		return fmt.Errorf("--rate must be greater than 0")
+	}
+
+	filter := &purgeFilter{botID: opts.fromBot}
+	if opts.match != "" {
+		re, err := regexp.Compile(opts.match)
+		if err != nil {
+			return fmt.Errorf("invalid --match: %w", err)
+		}
+		filter.match = re
+	}
+
+	now := time.Now()
+	var oldest, latest string
+	var err error
+	if opts.after != "" {
+		if oldest, err = validate.ParseTimeBound(opts.after, now); err != nil {
+			return fmt.Errorf("--after: %w", err)
+		}
+	}
+	if opts.before != "" {
+		if latest, err = validate.ParseTimeBound(opts.before, now); err != nil {
+			return fmt.Errorf("--before: %w", err)
+		}
+	}
+	if oldest != "" && latest != "" && client.CompareTS(oldest, latest) >= 0 {
+		return fmt.Errorf("--after must be before --before")
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.fromUser != "" {
+		if filter.userID, err = c.ResolveUserID(opts.fromUser); err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+
+	targets, err := purgeTargets(c, channelID, filter, oldest, latest, opts.replies)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		output.Println("No messages match")
+		return nil
+	}
+
+	resolver := client.NewUserResolver(c)
+	rows := make([][]string, 0, len(targets))
+	for _, m := range targets {
+		body, _ := messageBody(m, resolver)
+		rows = append(rows, []string{m.TS, formatTimestamp(m.TS), messageAuthor(m, resolver), truncate(body, 60)})
+	}
+	output.Table([]string{"TS", "TIME", "AUTHOR", "TEXT"}, rows)
+
+	if client.DryRun() {
+		output.Printf("Dry run: %d messages would be deleted\n", len(targets))
+		return nil
+	}
+
+	if !opts.force {
+		reader := opts.stdin
+		if reader == nil {
+			reader = os.Stdin
+		}
+
+		output.Printf("About to delete %d messages in channel %s\n", len(targets), channel)
+		output.Printf("Are you sure? [y/N]: ")
+
+		// No answer (EOF, nothing on stdin) is not a yes
+		scanner := bufio.NewScanner(reader)
+		if !scanner.Scan() {
+			output.Println("Cancelled.")
+			return nil
+		}
+		confirm := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if confirm != "y" && confirm != "yes" {
+			output.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	limiter := client.NewRateLimiter(opts.rate)
+	defer limiter.Stop()
+
+	var failed [][]string
+	for _, m := range targets {
+		err := client.WithRetry(maxPurgeAttempts, limiter.C, func() error {
+			return c.DeleteMessage(channelID, m.TS)
+		})
+		if err != nil {
+			failed = append(failed, []string{m.TS, client.WrapError("delete message", err).Error()})
+			continue
+		}
+		c.Record(journal.Entry{
+			Action: journal.ActionDelete, Channel: channelID, TS: m.TS,
+			Previous: m.Text, PreviousBlocks: m.RawBlocks(), HasPrevious: true,
+		})
+	}
+
+	output.Printf("Deleted %d of %d messages\n", len(targets)-len(failed), len(targets))
+	if len(failed) > 0 {
+		output.Table([]string{"TS", "ERROR"}, failed)
+		return fmt.Errorf("%d messages could not be deleted", len(failed))
+	}
+	return nil
+}
+
+// purgeTargets pages the channel history (and thread replies when asked)
+// and returns the messages that match, oldest first.
+func purgeTargets(c *client.Client, channelID string, filter *purgeFilter, oldest, latest string, replies bool) ([]client.Message, error) {
+	msgs, err := c.GetChannelHistory(channelID, 0, oldest, latest)
+	if err != nil {
+		return nil, client.WrapError("get history", err)
+	}
+
+	var targets []client.Message
+	for _, m := range msgs {
+		if filter.matches(m) {
+			targets = append(targets, m)
+		}
+		if !replies || m.ReplyCount == 0 {
+			continue
+		}
+		thread, err := c.GetThreadReplies(channelID, m.TS, 0, "")
+		if err != nil {
+			return nil, client.WrapError(fmt.Sprintf("get replies for %s", m.TS), err)
+		}
+		for _, r := range thread {
+			if r.TS == m.TS || client.CompareTS(r.TS, oldest) <= 0 {
+				continue
+			}
+			if latest != "" && client.CompareTS(r.TS, latest) >= 0 {
+				continue
+			}
+			if filter.matches(r) {
+				targets = append(targets, r)
+			}
+		}
+	}
+	client.SortByTS(targets)
+	return targets, nil
+}
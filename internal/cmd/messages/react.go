@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
@@ -16,11 +17,22 @@ func newReactCmd() *cobra.Command {
 	opts := &reactOptions{}
 
 	return &cobra.Command{
-		Use:   "react <channel> <timestamp> <emoji>",
+		Use:   "react {<ref> | <channel> <timestamp>} <emoji>",
 		Short: "Add a reaction to a message",
-		Args:  cobra.ExactArgs(3),
+		Long: `Add a reaction to a message.
+
+` + messageRefHelp + `
+
+Examples:
+  slck messages react C1234567890 1234567890.123456 thumbsup
+  slck messages react C1234567890/1234567890.123456 thumbsup`,
+		Args: messageArgs(1, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReact(args[0], args[1], args[2], opts, nil)
+			channel, timestamp, rest, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return runReact(channel, timestamp, rest[0], opts, nil)
 		},
 	}
 }
@@ -55,6 +67,7 @@ func runReact(channel, timestamp, emoji string, opts *reactOptions, c *client.Cl
 		}
 		return client.WrapError(fmt.Sprintf("add reaction :%s:", emoji), err)
 	}
+	c.Record(journal.Entry{Action: journal.ActionReact, Channel: channelID, TS: timestamp, Reaction: emoji})
 
 	output.Printf("Added :%s: reaction\n", emoji)
 	return nil
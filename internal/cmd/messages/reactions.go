@@ -0,0 +1,180 @@
+package messages
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+type reactionsOptions struct {
+	tally bool
+	since string
+	until string
+}
+
+func newReactionsCmd() *cobra.Command {
+	opts := &reactionsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "reactions {<ref> | <channel> [timestamp]}",
+		Short: "List who reacted to a message, or tally reactions",
+		Long: `List every reaction on a message with the names of the people who added it.
+
+` + messageRefHelp + `
+
+With --tally, reactions are counted across a whole thread (when given a
+message) or across the channel history between --since and --until (when
+given only a channel) — handy for lightweight polls and approvals.
+
+Examples:
+  slck messages reactions C1234567890/1234567890.123456
+  slck messages reactions C1234567890 1234567890.123456 --tally
+  slck messages reactions releases --tally --since 7d`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !isMessageRef(args[0]) {
+				return nil // a channel alone; runReactions requires --tally
+			}
+			return messageArgs(0, 0)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !isMessageRef(args[0]) {
+				return runReactions(args[0], "", opts, nil)
+			}
+			channel, timestamp, _, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return runReactions(channel, timestamp, opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.tally, "tally", false, "Count reactions across a thread or a history window")
+	cmd.Flags().StringVar(&opts.since, "since", "", "With a channel and --tally: only messages after this time (e.g. 7d)")
+	cmd.Flags().StringVar(&opts.until, "until", "", "With a channel and --tally: only messages before this time")
+
+	return cmd
+}
+
+func runReactions(channel, timestamp string, opts *reactionsOptions, c *client.Client) error {
+	var oldest, latest string
+	switch {
+	case timestamp == "" && !opts.tally:
+		return fmt.Errorf("a channel without a message timestamp requires --tally")
+	case timestamp != "" && (opts.since != "" || opts.until != ""):
+		return fmt.Errorf("--since and --until only apply when tallying a channel")
+	case timestamp == "":
+		var err error
+		oldest, latest, err = historyRange(&historyOptions{since: opts.since, until: opts.until}, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+
+	var msgs []client.Message
+	switch {
+	case !opts.tally:
+		msg, err := c.GetReactions(channelID, timestamp)
+		if err != nil {
+			return client.WrapError("get reactions", err)
+		}
+		msgs = []client.Message{*msg}
+	case timestamp != "":
+		if msgs, err = c.GetThreadReplies(channelID, timestamp, 0, ""); err != nil {
+			return client.WrapError("get thread", err)
+		}
+	default:
+		if msgs, err = c.GetChannelHistory(channelID, 0, oldest, latest); err != nil {
+			return client.WrapError("get history", err)
+		}
+	}
+
+	tallies := tallyReactions(msgs)
+	if len(tallies) == 0 {
+		output.Println("No reactions found")
+		return nil
+	}
+
+	resolver := client.NewUserResolver(c)
+	rows := make([][]string, 0, len(tallies))
+	for _, t := range tallies {
+		names := make([]string, 0, len(t.users))
+		for _, u := range t.users {
+			names = append(names, resolver.Resolve(u))
+		}
+		if t.unlisted > 0 {
+			names = append(names, fmt.Sprintf("and %d more", t.unlisted))
+		}
+		rows = append(rows, []string{":" + t.name + ":", strconv.Itoa(t.count), strings.Join(names, ", ")})
+	}
+	output.Table([]string{"EMOJI", "COUNT", "USERS"}, rows)
+
+	if opts.tally {
+		output.Printf("%d messages counted\n", len(msgs))
+	}
+	return nil
+}
+
+// reactionTally is one emoji's total across the tallied messages. users
+// holds each reacting user once, in first-seen order; unlisted counts
+// reactions whose users Slack left out (history truncates busy reactions).
+type reactionTally struct {
+	name     string
+	count    int
+	users    []string
+	unlisted int
+}
+
+// tallyReactions sums reactions per emoji, most used first. Skin-tone
+// variants (thumbsup::skin-tone-2) are counted as separate emoji, as Slack
+// shows them.
+func tallyReactions(msgs []client.Message) []reactionTally {
+	byName := map[string]*reactionTally{}
+	seen := map[string]map[string]bool{}
+	var order []string
+	for _, m := range msgs {
+		for _, r := range m.Reactions {
+			t, ok := byName[r.Name]
+			if !ok {
+				t = &reactionTally{name: r.Name}
+				byName[r.Name] = t
+				seen[r.Name] = map[string]bool{}
+				order = append(order, r.Name)
+			}
+			t.count += r.Count
+			t.unlisted += max(r.Count-len(r.Users), 0)
+			for _, u := range r.Users {
+				if !seen[r.Name][u] {
+					seen[r.Name][u] = true
+					t.users = append(t.users, u)
+				}
+			}
+		}
+	}
+
+	out := make([]reactionTally, 0, len(order))
+	for _, name := range order {
+		out = append(out, *byName[name])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].count > out[j].count })
+	return out
+}
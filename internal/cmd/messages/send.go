@@ -12,26 +12,82 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
 
+// sendEditorHelp is shown below the draft opened by send --edit.
+const sendEditorHelp = `# Write the message above the line marked >8; that line and everything
+# below it are removed. Save an empty or unchanged message to abort.
+`
+
 type sendOptions struct {
-	channel     string
-	threadTS    string
-	blocksJSON  string
-	blocksFile  string
-	blocksStdin bool
-	simple      bool
-	noUnfurl    bool
-	files       []string
-	fileTitle   string
-	permalink   bool
-	stdin       io.Reader // For testing
+	channel        string
+	threadTS       string
+	blocksJSON     string
+	blocksFile     string
+	blocksStdin    bool
+	noValidate     bool
+	simple         bool
+	markdown       bool
+	mdFile         string
+	template       string
+	dataFile       string
+	sets           []string
+	noUnfurl       bool
+	noUnfurlMedia  bool
+	replyBroadcast bool
+	username       string
+	iconEmoji      string
+	iconURL        string
+	parse          string
+	noMrkdwn       bool
+	linkNames      bool
+	edit           bool
+	metadataType   string
+	metadataFile   string
+	metadata       *client.Metadata
+	files          []string
+	fileTitle      string
+	permalink      bool
+	splitMode      string
+	to             []string
+	toFile         string
+	concurrency    int
+	rate           float64
+	sent           messageref.Ref // Set once a single message is posted
+	stdin          io.Reader      // For testing
+}
+
+// broadcasting reports whether the message goes to a --to/--to-file list
+// rather than a single channel.
+func (o *sendOptions) broadcasting() bool {
+	return len(o.to) > 0 || o.toFile != ""
+}
+
+// postOptions returns the optional chat.postMessage arguments set by flags.
+func (o *sendOptions) postOptions() client.PostOptions {
+	iconEmoji := o.iconEmoji
+	if iconEmoji != "" {
+		iconEmoji = ":" + strings.Trim(iconEmoji, ":") + ":"
+	}
+	return client.PostOptions{
+		Username:       o.username,
+		IconEmoji:      iconEmoji,
+		IconURL:        o.iconURL,
+		ReplyBroadcast: o.replyBroadcast,
+		NoUnfurlMedia:  o.noUnfurlMedia,
+		Parse:          o.parse,
+		NoMrkdwn:       o.noMrkdwn,
+		Metadata:       o.metadata,
+	}
 }
 
 func newSendCmd() *cobra.Command {
 	opts := &sendOptions{}
+	mrkdwn := true
 
 	cmd := &cobra.Command{
 		Use:   "send <channel> [text]",
@@ -49,6 +105,11 @@ Use "-" as the text argument to read message text from stdin:
   echo "Hello" | slck messages send C1234567890 -
   cat message.txt | slck messages send C1234567890 -
 
+Use --edit to write the message in $VISUAL or $EDITOR (default vi); any text
+argument becomes the starting draft. Saving an empty or unchanged draft
+aborts without sending:
+  slck messages send announcements --edit --markdown
+
 BLOCK KIT OPTIONS
 
 Text is optional when providing blocks via any of these methods:
@@ -67,6 +128,50 @@ Examples:
   slck messages send C1234567890 --blocks-file ./report.json
   generate-report | slck messages send C1234567890 --blocks-stdin
 
+Blocks are checked locally before sending (block types, required fields,
+text and block-count limits, duplicate IDs); problems are reported with a
+JSON path such as blocks[3].text.text. Use --no-validate to skip the check
+and let Slack decide.
+
+MARKDOWN
+
+  --markdown        Treat the message text as Markdown and convert it to
+                    Block Kit: headings become header blocks, lists become
+                    rich-text lists, fenced code and tables become
+                    preformatted blocks, --- becomes a divider, and a
+                    <sub>…</sub> line becomes a context block.
+  --markdown-file   Read Markdown from a file ("-" for stdin). Implies
+                    --markdown.
+
+Examples:
+  slck messages send C1234567890 --markdown "**Deploy done** for _api_"
+  slck messages send C1234567890 --markdown-file ./report.md
+  generate-report.sh | slck messages send C1234567890 --markdown-file -
+
+TEMPLATES
+
+  --template        Render a Go text/template file. A .json template produces
+                    Block Kit JSON and is sent exactly like --blocks-file;
+                    any other template produces the message text (combine
+                    with --markdown to convert it).
+  --data            YAML or JSON file of template values.
+  --set key=value   Set a template value (repeatable; overrides --data).
+
+Template helpers:
+  {{ escape .summary }}    Escape &, < and > for Slack mrkdwn
+  {{ json .summary }}      Encode a value as JSON (for .json templates)
+  {{ user .owner }}        Mention a user by ID or @handle
+  {{ channel .room }}      Link a channel by ID or name
+  {{ .eta | default "tbd" }}  Fall back when a value is empty
+
+Referencing a key that is missing from the data is an error, so a value that
+may be absent needs index, which yields nothing for a missing key:
+  {{ default "tbd" (index . "eta") }}
+
+Examples:
+  slck messages send deploys --template deploy.json --data release.yml
+  slck messages send deploys --template deploy.md --markdown --set version=1.4.2
+
 FILE UPLOADS
 
   --file            Upload a file to the channel. Can be specified multiple
@@ -80,13 +185,92 @@ Examples:
   slck messages send C1234567890 --file ./report.pdf --file-title "Monthly Report"
   slck messages send C1234567890 --file ./a.csv --file ./b.csv
 
+BROADCAST
+
+  --to              Destination (channel or user, as for the channel
+                    argument). Repeat to send the same message to many
+                    destinations; all positional arguments are then text.
+  --to-file         Read destinations from a file, one per line.
+  --concurrency     Maximum posts in flight (default 4).
+  --rate            Maximum posts per second across all destinations
+                    (default 4).
+
+Every destination is resolved before anything is sent. Results are printed
+as a table with permalinks; if any post fails the command exits non-zero and
+prints the --to flags needed to retry just the failures.
+
+Examples:
+  slck messages send --to general --to eng --to @alice "Maintenance at 17:00"
+  slck messages send --to-file teams.txt --markdown-file ./notice.md
+
+DELIVERY AND IDENTITY
+
+  --broadcast       With --thread, also show the reply in the channel.
+  --username        Post under a custom name.
+  --icon-emoji      Post with an emoji avatar (e.g. :rocket:).
+  --icon-url        Post with an image avatar.
+  --no-unfurl-media Keep link previews but skip media previews.
+  --parse           Slack text parsing: "full" links bare names and URLs,
+                    "none" leaves the text untouched.
+  --mrkdwn=false    Show *, _ and ~ literally instead of formatting.
+
+--username and the icon flags need the chat:write.customize scope on a bot
+token, and let one app post as several distinct services. --parse and
+--mrkdwn=false act on the message text, so they send plain text like
+--simple and cannot be combined with blocks or Markdown.
+
+Examples:
+  slck messages send alerts "Disk 91% full" --username "Disk Monitor" --icon-emoji :floppy_disk:
+  slck messages send deploys "Rolled back" --thread 1234567890.123456 --broadcast
+
+MENTIONS
+
+  --link-names      Turn @handle, @group, #channel, @here and @channel in
+                    the text into mentions Slack notifies.
+
+Plain "@alice" in message text is shown literally and pings nobody. With
+--link-names, handles are matched against users (username, then display
+name) and user groups, and #names against channels. Mentions that match no
+single user, group or channel are sent as typed and listed in a warning.
+Code spans and existing <...> tokens are left alone. Linking user groups
+needs the usergroups:read scope.
+
+Examples:
+  slck messages send deploys "@alice please check #incidents" --link-names
+
+LONG MESSAGES
+
+Text over Slack's 40,000-character limit, or that converts to more than 50
+blocks, is split into several messages at paragraph breaks, keeping fenced
+code blocks together where possible. Block Kit payloads with more than 50
+blocks are split the same way. The first part is posted as usual and the
+rest as replies in its thread; with --split-mode channel they are posted
+after it instead. The permalink of every part is printed.
+
+  --split-mode      thread (default) or channel.
+
+METADATA
+
+  --metadata-type   Attach Slack message metadata with this event type.
+  --metadata-file   JSON object to use as the metadata payload.
+
+Metadata is invisible in Slack but returned by 'history', 'thread' and
+'read', so automation can find its own messages without matching text.
+
+Examples:
+  slck messages send deploys "Deploying api 1.4.2" --metadata-type deploy_started --metadata-file payload.json
+
 The channel can also be specified via --channel instead of as a positional argument:
   slck messages send --channel general "Hello team"`,
 		Args: cobra.RangeArgs(0, 2),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.noMrkdwn = !mrkdwn
 			channel := opts.channel
 			text := ""
 			switch {
+			case opts.broadcasting():
+				// Destinations come from --to/--to-file, positional args are text
+				text = strings.Join(args, " ")
 			case channel != "" && len(args) > 0:
 				// --channel provided, positional args are text
 				text = strings.Join(args, " ")
@@ -110,11 +294,33 @@ The channel can also be specified via --channel instead of as a positional argum
 	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Inline Block Kit JSON array (for simple blocks)")
 	cmd.Flags().StringVar(&opts.blocksFile, "blocks-file", "", "Read blocks from JSON file (recommended for complex payloads)")
 	cmd.Flags().BoolVar(&opts.blocksStdin, "blocks-stdin", false, "Read blocks from stdin (for piping from other tools)")
+	cmd.Flags().BoolVar(&opts.noValidate, "no-validate", false, "Skip local Block Kit validation of --blocks/--blocks-file/--blocks-stdin")
 	cmd.Flags().BoolVar(&opts.simple, "simple", false, "Send as plain text without block formatting")
+	cmd.Flags().BoolVar(&opts.markdown, "markdown", false, "Convert the message text from Markdown to Block Kit")
+	cmd.Flags().StringVar(&opts.mdFile, "markdown-file", "", "Read Markdown from a file and convert it to Block Kit (- for stdin)")
+	cmd.Flags().StringVar(&opts.template, "template", "", "Render message text or Block Kit JSON (.json) from a Go template file")
+	cmd.Flags().StringVar(&opts.dataFile, "data", "", "YAML or JSON file of values for --template")
+	cmd.Flags().StringArrayVar(&opts.sets, "set", nil, "Template value as key=value (can be specified multiple times)")
 	cmd.Flags().BoolVar(&opts.noUnfurl, "no-unfurl", false, "Disable link preview unfurling")
+	cmd.Flags().BoolVar(&opts.noUnfurlMedia, "no-unfurl-media", false, "Disable media previews (link previews are kept)")
+	cmd.Flags().BoolVar(&opts.replyBroadcast, "broadcast", false, "Also show a --thread reply in the channel")
+	cmd.Flags().StringVar(&opts.username, "username", "", "Post under a custom name (requires chat:write.customize)")
+	cmd.Flags().StringVar(&opts.iconEmoji, "icon-emoji", "", "Post with an emoji avatar (requires chat:write.customize)")
+	cmd.Flags().StringVar(&opts.iconURL, "icon-url", "", "Post with an image avatar (requires chat:write.customize)")
+	cmd.Flags().StringVar(&opts.parse, "parse", "", "Slack text parsing mode: full or none")
+	cmd.Flags().BoolVar(&mrkdwn, "mrkdwn", true, "Format the text as mrkdwn (--mrkdwn=false shows it literally)")
+	cmd.Flags().BoolVar(&opts.edit, "edit", false, "Write the message text in $EDITOR before sending")
+	cmd.Flags().BoolVar(&opts.linkNames, "link-names", false, "Turn @handles, @groups, #channels, @here and @channel into real mentions")
+	cmd.Flags().StringVar(&opts.metadataType, "metadata-type", "", "Attach message metadata with this event type")
+	cmd.Flags().StringVar(&opts.metadataFile, "metadata-file", "", "JSON object file to use as the metadata payload")
 	cmd.Flags().StringArrayVar(&opts.files, "file", nil, "File(s) to upload (can be specified multiple times)")
 	cmd.Flags().StringVar(&opts.fileTitle, "file-title", "", "Custom title for uploaded file(s)")
+	cmd.Flags().StringVar(&opts.splitMode, "split-mode", splitModeThread, "Where the rest of an oversized message goes: thread or channel")
 	cmd.Flags().BoolVar(&opts.permalink, "permalink", false, "After sending, fetch and include the message permalink (one extra API call)")
+	cmd.Flags().StringArrayVar(&opts.to, "to", nil, "Broadcast destination (can be specified multiple times)")
+	cmd.Flags().StringVar(&opts.toFile, "to-file", "", "Read broadcast destinations from a file, one per line")
+	cmd.Flags().IntVar(&opts.concurrency, "concurrency", defaultBroadcastConcurrency, "Maximum concurrent posts when broadcasting")
+	cmd.Flags().Float64Var(&opts.rate, "rate", defaultBroadcastRate, "Maximum posts per second when broadcasting")
 
 	return cmd
 }
@@ -128,6 +334,32 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		opts.threadTS = validate.NormalizeTimestamp(opts.threadTS)
 	}
 
+	// Validate delivery and identity options
+	if opts.replyBroadcast && opts.threadTS == "" {
+		return fmt.Errorf("--broadcast requires --thread")
+	}
+	if opts.iconEmoji != "" && opts.iconURL != "" {
+		return fmt.Errorf("--icon-emoji and --icon-url cannot be used together")
+	}
+	switch opts.parse {
+	case "", "full", "none":
+	default:
+		return fmt.Errorf("invalid --parse %q: must be full or none", opts.parse)
+	}
+	switch opts.splitMode {
+	case "", splitModeThread, splitModeChannel:
+	default:
+		return fmt.Errorf("invalid --split-mode %q: must be thread or channel", opts.splitMode)
+	}
+	metadata, err := loadMetadata(opts.metadataType, opts.metadataFile)
+	if err != nil {
+		return err
+	}
+	opts.metadata = metadata
+	if len(opts.files) > 0 && opts.postOptions() != (client.PostOptions{}) {
+		return fmt.Errorf("--broadcast, --username, --icon-emoji, --icon-url, --no-unfurl-media, --parse, --mrkdwn and --metadata-type cannot be used with --file")
+	}
+
 	// Validate mutually exclusive blocks options
 	blocksOptionsCount := 0
 	if opts.blocksJSON != "" {
@@ -143,6 +375,112 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		return fmt.Errorf("only one of --blocks, --blocks-file, or --blocks-stdin can be specified")
 	}
 
+	// Validate broadcast options
+	var destinations []string
+	if opts.broadcasting() {
+		switch {
+		case channel != "":
+			return fmt.Errorf("--to and --to-file cannot be combined with a channel argument or --channel")
+		case opts.threadTS != "":
+			return fmt.Errorf("--thread cannot be used when broadcasting")
+		case len(opts.files) > 0:
+			return fmt.Errorf("--file cannot be used when broadcasting")
+		case opts.concurrency <= 0:
+			return fmt.Errorf("--concurrency must be at least 1")
+		case opts.rate <= 0:
+			return fmt.Errorf("--rate must be greater than 0")
+		}
+		var err error
+		if destinations, err = broadcastDestinations(opts.to, opts.toFile); err != nil {
+			return err
+		}
+	}
+
+	// --parse and --mrkdwn=false act on the text, which blocks would hide
+	plainText := opts.parse != "" || opts.noMrkdwn
+	if plainText && blocksOptionsCount > 0 {
+		return fmt.Errorf("--parse and --mrkdwn=false cannot be combined with --blocks, --blocks-file, or --blocks-stdin")
+	}
+	if opts.linkNames && blocksOptionsCount > 0 {
+		return fmt.Errorf("--link-names cannot be combined with --blocks, --blocks-file, or --blocks-stdin")
+	}
+
+	// Validate Markdown options
+	markdown := opts.markdown || opts.mdFile != ""
+	if markdown {
+		switch {
+		case blocksOptionsCount > 0:
+			return fmt.Errorf("--markdown cannot be combined with --blocks, --blocks-file, or --blocks-stdin")
+		case opts.simple:
+			return fmt.Errorf("--markdown cannot be combined with --simple")
+		case len(opts.files) > 0:
+			return fmt.Errorf("--markdown cannot be combined with --file")
+		case plainText:
+			return fmt.Errorf("--markdown cannot be combined with --parse or --mrkdwn=false")
+		}
+	}
+	if opts.mdFile != "" {
+		if text != "" {
+			return fmt.Errorf("cannot use message text and --markdown-file together")
+		}
+		md, err := readMarkdownFile(opts.mdFile, opts.stdin)
+		if err != nil {
+			return err
+		}
+		text = md
+	}
+
+	// Render --template into either the message text or a blocks payload
+	var templateBlocks string
+	if opts.template == "" && (opts.dataFile != "" || len(opts.sets) > 0) {
+		return fmt.Errorf("--data and --set require --template")
+	}
+	if opts.template != "" {
+		switch {
+		case blocksOptionsCount > 0:
+			return fmt.Errorf("--template cannot be combined with --blocks, --blocks-file, or --blocks-stdin")
+		case opts.mdFile != "":
+			return fmt.Errorf("--template cannot be combined with --markdown-file")
+		case text != "":
+			return fmt.Errorf("cannot use message text and --template together")
+		case markdown && isBlocksTemplate(opts.template):
+			return fmt.Errorf("--markdown cannot be combined with a Block Kit (.json) --template")
+		case plainText && isBlocksTemplate(opts.template):
+			return fmt.Errorf("--parse and --mrkdwn=false cannot be combined with a Block Kit (.json) --template")
+		case opts.linkNames && isBlocksTemplate(opts.template):
+			return fmt.Errorf("--link-names cannot be combined with a Block Kit (.json) --template")
+		}
+		getClient := func() (*client.Client, error) {
+			if c == nil {
+				var err error
+				if c, err = client.New(); err != nil {
+					return nil, err
+				}
+			}
+			return c, nil
+		}
+		tmpl, err := renderTemplate(opts.template, opts.dataFile, opts.sets, getClient)
+		if err != nil {
+			return err
+		}
+		if tmpl.blocks {
+			templateBlocks = tmpl.output
+		} else {
+			text = tmpl.output
+		}
+	}
+
+	if opts.edit {
+		switch {
+		case text == "-":
+			return fmt.Errorf("cannot use '-' for text and --edit together")
+		case opts.mdFile != "":
+			return fmt.Errorf("--edit cannot be combined with --markdown-file")
+		case opts.template != "":
+			return fmt.Errorf("--edit cannot be combined with --template")
+		}
+	}
+
 	// Read from stdin if text is "-"
 	if text == "-" {
 		if opts.blocksStdin {
@@ -167,11 +505,39 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 	}
 
 	// Unescape shell-escaped characters (e.g., \! from zsh)
-	text = unescapeShellChars(text)
+	if opts.mdFile == "" && opts.template == "" {
+		text = unescapeShellChars(text)
+	}
+
+	if opts.edit {
+		var err error
+		if text, err = editMessage(text, sendEditorHelp); err != nil {
+			return err
+		}
+	}
+
+	if opts.linkNames && text != "" {
+		if c == nil {
+			var err error
+			if c, err = client.New(); err != nil {
+				return err
+			}
+		}
+		linked, unresolved, err := client.NewNameLinker(c).Link(text)
+		if err != nil {
+			return fmt.Errorf("linking names: %w", err)
+		}
+		if len(unresolved) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: not linked (no single matching user, group or channel): %s\n", strings.Join(unresolved, ", "))
+		}
+		text = linked
+	}
 
 	// Determine blocks source
 	var blocksSource string
-	if opts.blocksJSON != "" {
+	if templateBlocks != "" {
+		blocksSource = templateBlocks
+	} else if opts.blocksJSON != "" {
 		blocksSource = opts.blocksJSON
 	} else if opts.blocksFile != "" {
 		data, err := os.ReadFile(opts.blocksFile)
@@ -198,6 +564,15 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		blocksSource = string(lines)
 	}
 
+	var blocks []interface{}
+	if blocksSource != "" {
+		var err error
+		// Validated below, once the blocks are grouped into messages
+		if blocks, err = parseBlocks(blocksSource, true); err != nil {
+			return err
+		}
+	}
+
 	// Validate: must have text, blocks, or files
 	hasBlocks := blocksSource != ""
 	hasFiles := len(opts.files) > 0
@@ -205,13 +580,57 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		return fmt.Errorf("message text cannot be empty (or provide blocks via --blocks, --blocks-file, --blocks-stdin, or files via --file)")
 	}
 
-	// Validate message length (file uploads use a different API path with no text limit)
-	if !hasFiles {
+	// A message to one destination that exceeds Slack's limits is split into
+	// several; a broadcast or a blocks fallback text must fit in one. File
+	// uploads use a different API path with no text limit.
+	split := destinations == nil && !hasFiles
+	if !hasFiles && (!split || hasBlocks) {
 		if err := validateMessageLength(text, false); err != nil {
 			return err
 		}
 	}
 
+	var toBlocks func(string) []interface{}
+	switch {
+	case hasBlocks || hasFiles:
+		// User-supplied blocks are used as given; file uploads carry text
+		// as the initial comment.
+	case markdown && text != "":
+		toBlocks = client.MarkdownToBlocks
+	case !opts.simple && !plainText && text != "":
+		// Default to block style for a more refined appearance
+		toBlocks = buildDefaultBlocks
+	}
+
+	parts := []messagePart{{text: text, blocks: blocks}}
+	switch {
+	case split && hasBlocks:
+		parts = splitBlocks(text, blocks)
+	case split:
+		parts = nil
+		for _, chunk := range splitText(text, fitsMessage(toBlocks)) {
+			parts = append(parts, messagePart{text: chunk})
+		}
+	}
+	for i := range parts {
+		switch {
+		case hasBlocks && !opts.noValidate:
+			if err := client.ValidateBlocks(parts[i].blocks); err != nil {
+				if len(parts) > 1 {
+					return fmt.Errorf("invalid blocks in message %d of %d (use --no-validate to send anyway):\n%w", i+1, len(parts), err)
+				}
+				return fmt.Errorf("invalid blocks (use --no-validate to send anyway):\n%w", err)
+			}
+		case markdown && parts[i].text != "":
+			var err error
+			if parts[i].blocks, err = markdownBlocks(parts[i].text); err != nil {
+				return err
+			}
+		case toBlocks != nil:
+			parts[i].blocks = toBlocks(parts[i].text)
+		}
+	}
+
 	if c == nil {
 		var err error
 		c, err = client.New()
@@ -220,6 +639,10 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		}
 	}
 
+	if destinations != nil {
+		return runBroadcast(destinations, text, parts[0].blocks, opts, c)
+	}
+
 	// Resolve channel name to ID if needed
 	channelID, err := c.ResolveMessageDestination(channel)
 	if err != nil {
@@ -231,25 +654,20 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		return uploadFiles(c, channelID, text, opts)
 	}
 
-	var blocks []interface{}
-	if blocksSource != "" {
-		if err := json.Unmarshal([]byte(blocksSource), &blocks); err != nil {
-			return fmt.Errorf("invalid blocks JSON: %w", err)
-		}
-	} else if !opts.simple && text != "" {
-		// Default to block style for a more refined appearance
-		blocks = buildDefaultBlocks(text)
+	if len(parts) > 1 {
+		return sendParts(c, channelID, parts, opts)
 	}
 
-	msg, err := c.SendMessage(channelID, text, opts.threadTS, blocks, !opts.noUnfurl)
+	msg, err := c.SendMessageWithOptions(channelID, text, opts.threadTS, parts[0].blocks, !opts.noUnfurl, opts.postOptions())
 	if err != nil {
 		return client.WrapError("send message", err)
 	}
+	c.Record(journal.Entry{Action: journal.ActionSend, Channel: channelID, TS: msg.TS, Text: text})
 
 	// chat.postMessage doesn't return a permalink, so fetch it on request.
 	// Best-effort: the message is already sent, so a failed permalink lookup
 	// warns rather than failing the command.
-	if opts.permalink {
+	if opts.permalink && !client.IsDryRunPlaceholder(msg.TS) {
 		if link, perr := c.GetPermalink(channelID, msg.TS); perr == nil {
 			msg.Permalink = link
 		} else {
@@ -257,6 +675,7 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 		}
 	}
 
+	opts.sent = messageref.Ref{ChannelID: channelID, TS: msg.TS}
 	output.Printf("Message sent (ts: %s)\n", msg.TS)
 	if msg.Permalink != "" {
 		output.Printf("%s\n", msg.Permalink)
@@ -265,57 +684,59 @@ func runSend(channel, text string, opts *sendOptions, c *client.Client) error {
 }
 
 func uploadFiles(c *client.Client, channelID, text string, opts *sendOptions) error {
-	var uploadedFiles []client.CompleteUploadExternalFile
-
+	uploads := make([]client.FileUpload, 0, len(opts.files))
 	for _, filePath := range opts.files {
 		info, err := os.Stat(filePath)
 		if err != nil {
 			return fmt.Errorf("cannot access file %s: %w", filePath, err)
 		}
-
-		filename := filepath.Base(filePath)
-		output.Printf("Uploading %s (%d bytes)...\n", filename, info.Size())
-
-		// Step 1: Get upload URL
-		uploadResp, err := c.GetUploadURLExternal(filename, info.Size())
-		if err != nil {
-			return client.WrapError("get upload URL", err)
-		}
-
-		// Step 2: Upload file bytes
 		f, err := os.Open(filePath)
 		if err != nil {
 			return fmt.Errorf("opening file %s: %w", filePath, err)
 		}
+		defer func() { _ = f.Close() }()
 
-		err = c.UploadFileToURL(uploadResp.UploadURL, f)
-		_ = f.Close()
-		if err != nil {
-			return client.WrapError("upload file", err)
-		}
-
-		title := opts.fileTitle
-		if title == "" {
-			title = filename
-		}
-
-		uploadedFiles = append(uploadedFiles, client.CompleteUploadExternalFile{
-			ID:    uploadResp.FileID,
-			Title: title,
-		})
+		filename := filepath.Base(filePath)
+		output.Printf("Uploading %s (%d bytes)...\n", filename, info.Size())
+		uploads = append(uploads, client.FileUpload{Name: filename, Title: opts.fileTitle, Content: f, Size: info.Size()})
 	}
 
-	// Step 3: Complete upload and share to channel/thread
-	err := c.CompleteUploadExternal(uploadedFiles, channelID, opts.threadTS, text)
+	ids, err := c.UploadFiles(uploads, channelID, opts.threadTS, text)
 	if err != nil {
-		return client.WrapError("complete upload", err)
+		return client.WrapError("upload files", err)
 	}
+	c.Record(journal.Entry{Action: journal.ActionUpload, Channel: channelID, Files: ids, Text: text})
 
-	if len(uploadedFiles) == 1 {
+	if len(uploads) == 1 {
 		output.Printf("File uploaded to channel %s\n", channelID)
 	} else {
-		output.Printf("%d files uploaded to channel %s\n", len(uploadedFiles), channelID)
+		output.Printf("%d files uploaded to channel %s\n", len(uploads), channelID)
 	}
 
 	return nil
 }
+
+// loadMetadata builds message metadata from --metadata-type and the JSON
+// object in --metadata-file. It returns nil when no type is given.
+func loadMetadata(eventType, payloadFile string) (*client.Metadata, error) {
+	if eventType == "" {
+		if payloadFile != "" {
+			return nil, fmt.Errorf("--metadata-file requires --metadata-type")
+		}
+		return nil, nil
+	}
+	payload := map[string]interface{}{}
+	if payloadFile != "" {
+		data, err := os.ReadFile(payloadFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading metadata file: %w", err)
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("metadata file must contain a JSON object: %w", err)
+		}
+		if payload == nil {
+			payload = map[string]interface{}{}
+		}
+	}
+	return &client.Metadata{EventType: eventType, EventPayload: payload}, nil
+}
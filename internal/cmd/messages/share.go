@@ -0,0 +1,159 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type shareOptions struct {
+	copy bool
+}
+
+func newShareCmd() *cobra.Command {
+	opts := &shareOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "share {<ref> | <channel> <timestamp>} <dest-channel> [comment]",
+		Short: "Share a message into another channel",
+		Long: `Share a message into another channel, optionally with a comment.
+
+By default the comment is posted with the message's permalink, which Slack
+unfurls into a preview of the original for everyone who can see it. With
+--copy the original's content is reposted instead, followed by a line
+crediting its author and linking back to it — useful when the destination's
+members cannot open the source channel. Files are not copied.
+
+` + messageRefHelp + `
+
+The destination is a channel name or ID, a user ID or @handle (for a DM).
+
+Examples:
+  slck messages share C1234567890/1234567890.123456 incidents "FYI, see this"
+  slck messages share https://example.slack.com/archives/C1234567890/p1234567890123456 @alice
+  slck messages share C1234567890 1234567890.123456 announcements --copy`,
+		Args: messageArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			channel, timestamp, rest, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			comment := ""
+			if len(rest) > 1 {
+				comment = rest[1]
+			}
+			return runShare(channel, timestamp, rest[0], comment, opts, nil)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.copy, "copy", false, "Repost the message's content with attribution instead of linking to it")
+
+	return cmd
+}
+
+func runShare(channel, timestamp, dest, comment string, opts *shareOptions, c *client.Client) error {
+	if err := validate.Timestamp(timestamp); err != nil {
+		return err
+	}
+	timestamp = validate.NormalizeTimestamp(timestamp)
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+	destID, err := c.ResolveMessageDestination(dest)
+	if err != nil {
+		return err
+	}
+
+	permalink, err := c.GetPermalink(channelID, timestamp)
+	if err != nil {
+		return client.WrapError("get permalink", err)
+	}
+
+	var msg *client.Message
+	var text string
+	if opts.copy {
+		source, err := c.GetMessage(channelID, timestamp)
+		if err != nil {
+			return client.WrapError("get message", err)
+		}
+		var blocks []interface{}
+		text, blocks, err = copiedMessage(*source, channelID, permalink, comment, client.NewUserResolver(c))
+		if err != nil {
+			return err
+		}
+		msg, err = c.SendMessage(destID, text, "", blocks, false)
+		if err != nil {
+			return client.WrapError("share message", err)
+		}
+	} else {
+		text = permalink
+		if comment != "" {
+			text = comment + "\n" + permalink
+		}
+		// Unfurling is what turns the permalink into a preview
+		msg, err = c.SendMessage(destID, text, "", nil, true)
+		if err != nil {
+			return client.WrapError("share message", err)
+		}
+	}
+
+	c.Record(journal.Entry{Action: journal.ActionSend, Channel: destID, TS: msg.TS, Text: text})
+	output.Printf("Message shared to %s/%s\n", destID, msg.TS)
+	return nil
+}
+
+// copiedMessage builds the text and blocks that repost m: the comment, the
+// original's blocks (or its rendered text when it has none) and a context
+// line crediting the author and linking back to the original.
+func copiedMessage(m client.Message, channelID, permalink, comment string, resolver *client.UserResolver) (string, []interface{}, error) {
+	var blocks []interface{}
+	if comment != "" {
+		blocks = append(blocks, buildDefaultBlocks(comment)...)
+	}
+
+	original := m.RawBlocks()
+	fallback, _ := messageBody(m, resolver)
+	if len(original) == 0 && fallback != "" {
+		original = buildDefaultBlocks(fallback)
+	}
+	blocks = append(blocks, original...)
+
+	// The resolved name, not a mention: a copy should not notify the author
+	author := "*" + client.EscapeMrkdwn(messageAuthor(m, resolver)) + "*"
+	blocks = append(blocks, map[string]interface{}{
+		"type": "context",
+		"elements": []interface{}{map[string]interface{}{
+			"type": "mrkdwn",
+			"text": fmt.Sprintf("Originally posted by %s in <#%s> · <%s|View original>", author, channelID, permalink),
+		}},
+	})
+	if len(blocks) > client.MaxBlocksPerMessage {
+		return "", nil, fmt.Errorf("the message has too many blocks to copy with attribution; share it without --copy")
+	}
+
+	text := fallback
+	switch {
+	case comment != "" && fallback != "":
+		text = comment + "\n\n" + fallback
+	case comment != "":
+		text = comment
+	case text == "":
+		text = "Shared message: " + permalink
+	}
+	return text, blocks, nil
+}
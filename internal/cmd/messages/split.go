@@ -0,0 +1,213 @@
+package messages
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// Values of send --split-mode.
+const (
+	splitModeThread  = "thread"
+	splitModeChannel = "channel"
+)
+
+// fenceRunes is room left in a part for a code fence reopened by a split.
+const fenceRunes = 32
+
+// messagePart is one message of a send that is split to fit Slack's limits.
+type messagePart struct {
+	text   string
+	blocks []interface{}
+}
+
+// splitText cuts text into parts that each satisfy fits, breaking between
+// paragraphs and never inside a code fence unless a single fenced block is
+// too big on its own, in which case every piece is re-fenced.
+func splitText(text string, fits func(string) bool) []string {
+	if fits(text) {
+		return []string{text}
+	}
+	join := func(segs []string) string { return strings.Join(segs, "\n\n") }
+	return pack(textSegments(text), join, fits, func(seg string) []string {
+		return splitSegment(seg, fits)
+	})
+}
+
+// pack joins consecutive items into the longest runs that satisfy fits.
+// Each run is found by an exponential then binary search, so a part costs a
+// logarithmic number of fits checks rather than one per item. An item that
+// does not fit on its own is handed to tooBig.
+func pack(items []string, join func([]string) string, fits func(string) bool, tooBig func(string) []string) []string {
+	var parts []string
+	for i := 0; i < len(items); {
+		remaining := len(items) - i
+		lo, n := 0, 1
+		for n <= remaining && fits(join(items[i:i+n])) {
+			lo, n = n, n*2
+		}
+		hi := min(n, remaining+1)
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			if fits(join(items[i : i+mid])) {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+
+		if lo == 0 {
+			parts = append(parts, tooBig(items[i])...)
+			i++
+			continue
+		}
+		parts = append(parts, join(items[i:i+lo]))
+		i += lo
+	}
+	return parts
+}
+
+// textSegments splits text into paragraphs at blank lines, keeping each
+// ``` fenced block (which may contain blank lines) in one segment.
+func textSegments(text string) []string {
+	var segs, cur []string
+	flush := func() {
+		if len(cur) > 0 {
+			segs = append(segs, strings.Join(cur, "\n"))
+			cur = nil
+		}
+	}
+
+	inFence := false
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		fence := strings.HasPrefix(trimmed, "```")
+		switch {
+		case inFence:
+			cur = append(cur, line)
+			if fence {
+				inFence = false
+				flush()
+			}
+		case fence:
+			flush()
+			cur = append(cur, line)
+			// ```inline``` on one line does not open a block
+			inFence = len(trimmed) < 6 || !strings.HasSuffix(trimmed, "```")
+		case trimmed == "":
+			flush()
+		default:
+			cur = append(cur, line)
+		}
+	}
+	flush()
+	return segs
+}
+
+// splitSegment cuts one oversized paragraph or fenced block at line
+// boundaries, and a line that is still too long at rune boundaries.
+func splitSegment(seg string, fits func(string) bool) []string {
+	lines := strings.Split(seg, "\n")
+	open, closing := "", ""
+	if len(lines) >= 2 && strings.HasPrefix(strings.TrimSpace(lines[0]), "```") && strings.TrimSpace(lines[len(lines)-1]) == "```" {
+		open, closing = lines[0], lines[len(lines)-1]
+		lines = lines[1 : len(lines)-1]
+	}
+	wrap := func(body []string) string {
+		if open == "" {
+			return strings.Join(body, "\n")
+		}
+		return open + "\n" + strings.Join(body, "\n") + "\n" + closing
+	}
+
+	return pack(lines, wrap, fits, func(line string) []string {
+		var pieces []string
+		runes := []rune(line)
+		for len(runes) > 0 {
+			n := min(len(runes), maxMessageTextLen-fenceRunes)
+			pieces = append(pieces, wrap([]string{string(runes[:n])}))
+			runes = runes[n:]
+		}
+		return pieces
+	})
+}
+
+// fitsMessage returns the check splitText uses for one message: the text is
+// within Slack's length limit and, when toBlocks is set, converts to no more
+// blocks than a message may hold.
+func fitsMessage(toBlocks func(string) []interface{}) func(string) bool {
+	return func(s string) bool {
+		if utf8.RuneCountInString(s) > maxMessageTextLen {
+			return false
+		}
+		return toBlocks == nil || len(toBlocks(s)) <= client.MaxBlocksPerMessage
+	}
+}
+
+// splitBlocks groups user-supplied blocks into messages of at most
+// client.MaxBlocksPerMessage. Only the first message carries the text.
+func splitBlocks(text string, blocks []interface{}) []messagePart {
+	var parts []messagePart
+	for len(blocks) > 0 {
+		n := min(len(blocks), client.MaxBlocksPerMessage)
+		parts = append(parts, messagePart{blocks: blocks[:n]})
+		blocks = blocks[n:]
+	}
+	parts[0].text = text
+	return parts
+}
+
+// sendParts posts a split message. The first part goes where a single
+// message would; with --split-mode thread the rest reply to it (or to
+// --thread), with channel they follow it at the same level. Metadata and
+// --broadcast apply to the first part only. Each part's permalink is
+// reported.
+func sendParts(c *client.Client, channelID string, parts []messagePart, opts *sendOptions) error {
+	postOpts := opts.postOptions()
+	rest := postOpts
+	rest.ReplyBroadcast = false
+	rest.Metadata = nil
+
+	var rows [][]string
+	report := func() {
+		output.Printf("Message split into %d parts\n", len(parts))
+		output.Table([]string{"PART", "TS", "PERMALINK"}, rows)
+	}
+
+	threadTS := opts.threadTS
+	for i, p := range parts {
+		msg, err := c.SendMessageWithOptions(channelID, p.text, threadTS, p.blocks, !opts.noUnfurl, postOpts)
+		if err != nil {
+			if len(rows) > 0 {
+				report()
+			}
+			return client.WrapError(fmt.Sprintf("send part %d of %d", i+1, len(parts)), err)
+		}
+		c.Record(journal.Entry{Action: journal.ActionSend, Channel: channelID, TS: msg.TS, Text: p.text})
+		if i == 0 {
+			opts.sent = messageref.Ref{ChannelID: channelID, TS: msg.TS}
+			postOpts = rest
+			if opts.splitMode != splitModeChannel && threadTS == "" {
+				threadTS = msg.TS
+			}
+		}
+
+		link := ""
+		if !client.IsDryRunPlaceholder(msg.TS) {
+			if link, err = c.GetPermalink(channelID, msg.TS); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: part %d sent (ts %s) but permalink fetch failed: %v\n", i+1, msg.TS, err)
+			}
+		}
+		rows = append(rows, []string{strconv.Itoa(i + 1), msg.TS, link})
+	}
+
+	report()
+	return nil
+}
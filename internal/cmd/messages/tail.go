@@ -0,0 +1,293 @@
+package messages
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// tailPollLimit bounds --limit, the number of messages shown when tailing
+// starts.
+const tailPollLimit = 1000
+
+// tailThreadChecks is how many followed threads one poll checks for new
+// replies. Each check is a conversations.replies call, so the followed
+// threads are cycled through a few at a time rather than all at once.
+const tailThreadChecks = 5
+
+// tailFatalErrors are Slack error codes that retrying cannot fix; any other
+// poll failure is treated as transient.
+var tailFatalErrors = []string{
+	"channel_not_found",
+	"not_in_channel",
+	"invalid_auth",
+	"token_revoked",
+	"missing_scope",
+	"account_inactive",
+}
+
+// tailFatal reports whether err is one of tailFatalErrors.
+func tailFatal(err error) bool {
+	for _, code := range tailFatalErrors {
+		if client.IsSlackError(err, code) {
+			return true
+		}
+	}
+	return false
+}
+
+type tailOptions struct {
+	limit       int
+	follow      bool
+	replies     bool
+	interval    time.Duration
+	maxInterval time.Duration
+}
+
+func newTailCmd() *cobra.Command {
+	opts := &tailOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tail <channel>",
+		Short: "Show the latest messages and optionally follow new ones",
+		Long: `Show the latest messages in a channel, oldest first.
+
+With --follow, keep polling for new messages and print them as they arrive
+until interrupted. Polling starts at --interval and backs off up to
+--max-interval while the channel is quiet, returning to --interval as soon as
+something arrives. Transient errors (network failures, rate limits) are
+reported on stderr and retried without losing or repeating messages.
+
+With --replies, new thread replies are printed too, prefixed with "↳", for
+the messages shown and every message that arrives while following. The
+followed threads are checked a few per poll, so in a busy channel replies
+can show up a little after the messages around them.
+
+Examples:
+  slck messages tail alerts
+  slck messages tail alerts --follow
+  slck messages tail alerts --follow --replies --limit 0 | grep -i error`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			return runTail(ctx, args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.limit, "limit", "n", 10, "Number of recent messages to show first")
+	cmd.Flags().BoolVarP(&opts.follow, "follow", "f", false, "Keep polling for new messages")
+	cmd.Flags().BoolVar(&opts.replies, "replies", false, "Also print new thread replies when following")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "Polling interval when messages are arriving")
+	cmd.Flags().DurationVar(&opts.maxInterval, "max-interval", 30*time.Second, "Longest polling interval when the channel is quiet")
+
+	return cmd
+}
+
+func runTail(ctx context.Context, channel string, opts *tailOptions, c *client.Client) error {
+	if opts.limit < 0 || opts.limit > tailPollLimit {
+		return fmt.Errorf("invalid limit %d: must be between 0 and %d", opts.limit, tailPollLimit)
+	}
+	if opts.follow && opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+
+	t := &tailer{
+		c:         c,
+		channelID: channelID,
+		resolver:  client.NewUserResolver(c),
+		replies:   opts.replies,
+		threads:   map[string]string{},
+	}
+	if err := t.start(opts.limit); err != nil {
+		return err
+	}
+	if !opts.follow {
+		return nil
+	}
+
+	maxInterval := max(opts.maxInterval, opts.interval)
+	delay := opts.interval
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		batch, err := t.poll()
+		if err != nil {
+			if tailFatal(err) {
+				return client.WrapError("poll channel", err)
+			}
+			fmt.Fprintf(os.Stderr, "warning: poll failed, retrying: %v\n", err)
+			delay = min(delay*2, maxInterval)
+			continue
+		}
+		if len(batch) == 0 {
+			delay = min(delay*2, maxInterval)
+			continue
+		}
+		t.print(batch)
+		delay = opts.interval
+	}
+}
+
+// tailer holds the polling cursors. State only advances after a poll has
+// fetched everything it needs, so a failed poll is simply repeated.
+type tailer struct {
+	c         *client.Client
+	channelID string
+	resolver  *client.UserResolver
+	replies   bool
+
+	// cursor is the newest top-level message printed; polls page forward
+	// from it.
+	cursor string
+	// threads maps each followed parent ts to the newest reply already seen
+	// (the parent's own ts while it has none).
+	threads map[string]string
+	// queue is the order followed threads are checked in: each poll checks
+	// the first tailThreadChecks and moves them to the back.
+	queue []string
+}
+
+// start prints the last limit messages and initializes the cursors.
+func (t *tailer) start(limit int) error {
+	// The newest message anchors the cursor even when none are shown
+	msgs, err := t.c.GetChannelHistory(t.channelID, max(limit, 1), "", "")
+	if err != nil {
+		return err
+	}
+	client.SortByTS(msgs)
+
+	if len(msgs) > 0 {
+		t.cursor = msgs[len(msgs)-1].TS
+	}
+	if limit == 0 {
+		msgs = nil
+	}
+	for _, m := range msgs {
+		t.follow(m)
+	}
+	t.print(msgs)
+	return nil
+}
+
+// follow starts watching m's thread for replies, from its newest reply.
+// Replies themselves (broadcast to the channel) are not threads.
+func (t *tailer) follow(m client.Message) {
+	if !t.replies || (m.ThreadTS != "" && m.ThreadTS != m.TS) {
+		return
+	}
+	if _, ok := t.threads[m.TS]; ok {
+		return
+	}
+	seen := m.TS
+	if m.LatestReply != "" {
+		seen = m.LatestReply
+	}
+	t.threads[m.TS] = seen
+	t.queue = append(t.queue, m.TS)
+}
+
+// poll returns messages that arrived since the previous poll and, with
+// replies, new replies in the next few followed threads, oldest first.
+func (t *tailer) poll() ([]client.Message, error) {
+	msgs, err := t.c.GetChannelHistory(t.channelID, 0, t.cursor, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []client.Message
+	cursor := t.cursor
+	for _, m := range msgs {
+		if client.CompareTS(m.TS, t.cursor) > 0 {
+			batch = append(batch, m)
+			if client.CompareTS(m.TS, cursor) > 0 {
+				cursor = m.TS
+			}
+		}
+	}
+
+	checked := t.queue[:min(tailThreadChecks, len(t.queue))]
+	threads := map[string]string{}
+	gone := map[string]bool{}
+	for _, parent := range checked {
+		seen := t.threads[parent]
+		replies, err := t.c.GetThreadReplies(t.channelID, parent, 0, seen)
+		switch {
+		case err == nil:
+		case tailFatal(err):
+			return nil, err
+		case client.IsSlackError(err, "thread_not_found"):
+			// The parent was deleted; there is nothing more to follow
+			gone[parent] = true
+			continue
+		default:
+			// One thread failing must not hold up the rest; it is
+			// checked again when its turn comes round
+			fmt.Fprintf(os.Stderr, "warning: checking thread %s failed: %v\n", parent, err)
+			continue
+		}
+		newest := seen
+		for _, r := range replies {
+			if r.TS == parent || client.CompareTS(r.TS, seen) <= 0 {
+				continue
+			}
+			batch = append(batch, r)
+			if client.CompareTS(r.TS, newest) > 0 {
+				newest = r.TS
+			}
+		}
+		threads[parent] = newest
+	}
+
+	t.cursor = cursor
+	for parent, newest := range threads {
+		t.threads[parent] = newest
+	}
+	queue := append([]string{}, t.queue[len(checked):]...)
+	for _, parent := range checked {
+		if gone[parent] {
+			delete(t.threads, parent)
+			continue
+		}
+		queue = append(queue, parent)
+	}
+	t.queue = queue
+	client.SortByTS(batch)
+	for _, m := range batch {
+		t.follow(m)
+	}
+	return batch, nil
+}
+
+func (t *tailer) print(msgs []client.Message) {
+	for _, m := range msgs {
+		prefix := ""
+		if m.ThreadTS != "" && m.ThreadTS != m.TS {
+			prefix = "↳ "
+		}
+		renderMessage(m, t.resolver, prefix)
+	}
+}
@@ -0,0 +1,139 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// messageTemplate is a rendered --template file. A .json template produces
+// Block Kit JSON; any other extension produces message text.
+type messageTemplate struct {
+	output string
+	blocks bool
+}
+
+// isBlocksTemplate reports whether a template path renders Block Kit JSON.
+func isBlocksTemplate(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// renderTemplate executes a Go text/template file against values loaded from
+// dataFile (YAML or JSON) overlaid with --set key=value pairs. getClient is
+// only called when a mention helper needs to look up a user or channel.
+func renderTemplate(path, dataFile string, sets []string, getClient func() (*client.Client, error)) (*messageTemplate, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading template: %w", err)
+	}
+
+	data, err := loadTemplateData(dataFile, sets)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).
+		Option("missingkey=error").
+		Funcs(templateFuncs(getClient)).
+		Parse(string(src))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+	return &messageTemplate{output: out.String(), blocks: isBlocksTemplate(path)}, nil
+}
+
+// loadTemplateData reads the --data file and applies --set overrides. Set
+// values are always strings; dotted keys are not expanded.
+func loadTemplateData(dataFile string, sets []string) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	if dataFile != "" {
+		raw, err := os.ReadFile(dataFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading template data: %w", err)
+		}
+		// YAML is a superset of JSON, so one decoder handles both.
+		if err := yaml.Unmarshal(raw, &data); err != nil {
+			return nil, fmt.Errorf("parsing template data %s: %w", dataFile, err)
+		}
+		if data == nil {
+			data = map[string]interface{}{}
+		}
+	}
+	for _, kv := range sets {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --set %q: expected key=value", kv)
+		}
+		data[key] = value
+	}
+	return data, nil
+}
+
+// templateFuncs returns the helpers available to message templates:
+//
+//	escape   Slack mrkdwn escaping (&, <, >) for untrusted values
+//	json     a value encoded as JSON, for splicing into Block Kit templates
+//	user     a user mention from an ID or @handle
+//	channel  a channel mention from an ID or name
+//	default  a fallback for empty values: {{ .owner | default "unassigned" }}
+//
+// Templates run with missingkey=error to catch misspelt keys, so a key that
+// may be absent from the data is read with index: {{ default "x" (index . "k") }}.
+func templateFuncs(getClient func() (*client.Client, error)) template.FuncMap {
+	return template.FuncMap{
+		"escape": func(v interface{}) string {
+			return client.EscapeMrkdwn(fmt.Sprint(v))
+		},
+		"json": func(v interface{}) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return string(b), nil
+		},
+		"user": func(user string) (string, error) {
+			if !client.IsUserID(user) {
+				c, err := getClient()
+				if err != nil {
+					return "", err
+				}
+				if user, err = c.ResolveUserID(user); err != nil {
+					return "", err
+				}
+			}
+			return "<@" + user + ">", nil
+		},
+		"channel": func(channel string) (string, error) {
+			channel = strings.TrimPrefix(channel, "#")
+			if !client.IsChannelID(channel) {
+				c, err := getClient()
+				if err != nil {
+					return "", err
+				}
+				if channel, err = c.ResolveChannel(channel); err != nil {
+					return "", err
+				}
+			}
+			return "<#" + channel + ">", nil
+		},
+		"default": func(fallback, v interface{}) interface{} {
+			if v == nil || fmt.Sprint(v) == "" {
+				return fallback
+			}
+			return v
+		},
+	}
+}
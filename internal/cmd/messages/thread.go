@@ -19,11 +19,22 @@ func newThreadCmd() *cobra.Command {
 	opts := &threadOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "thread <channel> <thread-ts>",
+		Use:   "thread {<ref> | <channel> <thread-ts>}",
 		Short: "Get thread replies",
-		Args:  cobra.ExactArgs(2),
+		Long: `Get the replies in a thread, starting with its parent message.
+
+` + messageRefHelp + `
+
+Examples:
+  slck messages thread C1234567890 1234567890.123456
+  slck messages thread C1234567890/1234567890.123456 --since 1234567899.000000`,
+		Args: messageArgs(0, 0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runThread(args[0], args[1], opts, nil)
+			channel, threadTS, _, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return runThread(channel, threadTS, opts, nil)
 		},
 	}
 
@@ -70,28 +81,37 @@ func runThread(channel, threadTS string, opts *threadOptions, c *client.Client)
 // attachment lines. Shared between `messages thread` and `messages read`.
 func renderMessageList(messages []client.Message, resolver *client.UserResolver) {
 	for _, m := range messages {
-		ts := formatTimestamp(m.TS)
-		body, preserveNewlines := messageBody(m, resolver)
-		var text string
-		if preserveNewlines {
-			text = indentContinuation(body)
-		} else {
-			text = flatten(body)
-		}
-		name := messageAuthor(m, resolver)
-		edited := ""
-		if m.Edited != nil {
-			edited = " [edited]"
-		}
-		if edited != "" {
-			if idx := strings.Index(text, "\n"); idx >= 0 {
-				text = text[:idx] + edited + text[idx:]
-				edited = ""
-			}
-		}
-		output.Printf("[%s] %s: %s%s\n", ts, name, text, edited)
-		if files := renderFiles(m.Files); files != "" {
-			output.Printf("%s", files)
+		renderMessage(m, resolver, "")
+	}
+}
+
+// renderMessage prints one message in the renderMessageList format, with
+// prefix written before the "[ts]" header.
+func renderMessage(m client.Message, resolver *client.UserResolver, prefix string) {
+	ts := formatTimestamp(m.TS)
+	body, preserveNewlines := messageBody(m, resolver)
+	var text string
+	if preserveNewlines {
+		text = indentContinuation(body)
+	} else {
+		text = flatten(body)
+	}
+	name := messageAuthor(m, resolver)
+	edited := ""
+	if m.Edited != nil {
+		edited = " [edited]"
+	}
+	if edited != "" {
+		if idx := strings.Index(text, "\n"); idx >= 0 {
+			text = text[:idx] + edited + text[idx:]
+			edited = ""
 		}
 	}
+	output.Printf("%s[%s] %s: %s%s\n", prefix, ts, name, text, edited)
+	if files := renderFiles(m.Files); files != "" {
+		output.Printf("%s", files)
+	}
+	if md := renderMetadata(m.Metadata); md != "" {
+		output.Printf("%s", md)
+	}
 }
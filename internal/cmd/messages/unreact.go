@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 	"github.com/open-cli-collective/slack-chat-api/internal/validate"
 )
@@ -16,11 +17,22 @@ func newUnreactCmd() *cobra.Command {
 	opts := &unreactOptions{}
 
 	return &cobra.Command{
-		Use:   "unreact <channel> <timestamp> <emoji>",
+		Use:   "unreact {<ref> | <channel> <timestamp>} <emoji>",
 		Short: "Remove a reaction from a message",
-		Args:  cobra.ExactArgs(3),
+		Long: `Remove a reaction from a message.
+
+` + messageRefHelp + `
+
+Examples:
+  slck messages unreact C1234567890 1234567890.123456 thumbsup
+  slck messages unreact C1234567890/1234567890.123456 thumbsup`,
+		Args: messageArgs(1, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUnreact(args[0], args[1], args[2], opts, nil)
+			channel, timestamp, rest, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return runUnreact(channel, timestamp, rest[0], opts, nil)
 		},
 	}
 }
@@ -55,6 +67,7 @@ func runUnreact(channel, timestamp, emoji string, opts *unreactOptions, c *clien
 		}
 		return client.WrapError(fmt.Sprintf("remove reaction :%s:", emoji), err)
 	}
+	c.Record(journal.Entry{Action: journal.ActionUnreact, Channel: channelID, TS: timestamp, Reaction: emoji})
 
 	output.Printf("Removed :%s: reaction\n", emoji)
 	return nil
@@ -1,47 +1,144 @@
 package messages
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 )
 
+// updateEditorHelp is shown below the text opened by update --edit.
+const updateEditorHelp = `# Edit the message above the line marked >8; that line and everything
+# below it are removed. Save an empty or unchanged message to abort.
+`
+
 type updateOptions struct {
 	blocksJSON string
+	noValidate bool
 	simple     bool
+	markdown   bool
+	mdFile     string
 	noUnfurl   bool
+	edit       bool
+	stdin      io.Reader // For testing
 }
 
 func newUpdateCmd() *cobra.Command {
 	opts := &updateOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "update <channel> <timestamp> <text>",
+		Use:   "update {<ref> | <channel> <timestamp>} [text]",
 		Short: "Update an existing message",
 		Long: `Update an existing message.
 
+` + messageRefHelp + `
+
 By default, messages are updated using Slack Block Kit formatting for a more
-refined appearance. Use --simple to update with plain text instead.`,
-		Args: cobra.ExactArgs(3),
+refined appearance. Use --simple to update with plain text instead.
+
+Use --markdown to convert the new text from Markdown to Block Kit, or
+--markdown-file to read the Markdown from a file ("-" for stdin), in which
+case the text argument is omitted:
+  slck messages update C1234567890 1234567890.123456 --markdown-file ./report.md
+  slck messages update C1234567890/1234567890.123456 "Fixed typo"
+
+Use --edit instead of the text argument to change the current text in
+$VISUAL or $EDITOR (default vi). Saving it empty or unchanged aborts
+without updating:
+  slck messages update C1234567890/1234567890.123456 --edit`,
+		Args: messageArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runUpdate(args[0], args[1], args[2], opts, nil)
+			channel, timestamp, rest, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			text := ""
+			if len(rest) > 0 {
+				text = rest[0]
+			}
+			return runUpdate(channel, timestamp, text, opts, nil)
 		},
 	}
 
 	cmd.Flags().StringVar(&opts.blocksJSON, "blocks", "", "Block Kit blocks as JSON array (overrides default block formatting)")
+	cmd.Flags().BoolVar(&opts.noValidate, "no-validate", false, "Skip local Block Kit validation of --blocks")
 	cmd.Flags().BoolVar(&opts.simple, "simple", false, "Update as plain text without block formatting")
+	cmd.Flags().BoolVar(&opts.markdown, "markdown", false, "Convert the new text from Markdown to Block Kit")
+	cmd.Flags().StringVar(&opts.mdFile, "markdown-file", "", "Read Markdown from a file and convert it to Block Kit (- for stdin)")
 	cmd.Flags().BoolVar(&opts.noUnfurl, "no-unfurl", false, "Disable link preview unfurling")
+	cmd.Flags().BoolVar(&opts.edit, "edit", false, "Edit the current message text in $EDITOR")
 
 	return cmd
 }
 
 func runUpdate(channel, timestamp, text string, opts *updateOptions, c *client.Client) error {
-	// Unescape shell-escaped characters (e.g., \! from zsh)
-	text = unescapeShellChars(text)
+	markdown := opts.markdown || opts.mdFile != ""
+	if markdown {
+		switch {
+		case opts.blocksJSON != "":
+			return fmt.Errorf("--markdown cannot be combined with --blocks")
+		case opts.simple:
+			return fmt.Errorf("--markdown cannot be combined with --simple")
+		}
+	}
+
+	if opts.edit {
+		switch {
+		case text != "":
+			return fmt.Errorf("cannot use message text and --edit together")
+		case opts.mdFile != "":
+			return fmt.Errorf("--edit cannot be combined with --markdown-file")
+		case opts.blocksJSON != "":
+			return fmt.Errorf("--edit cannot be combined with --blocks")
+		}
+
+		if c == nil {
+			var err error
+			if c, err = client.New(); err != nil {
+				return err
+			}
+		}
+		channelID, err := c.ResolveMessageDestination(channel)
+		if err != nil {
+			return err
+		}
+		current, err := c.GetMessage(channelID, timestamp)
+		if err != nil {
+			return client.WrapError("get message", err)
+		}
+		if text, err = editMessage(current.Text, updateEditorHelp); err != nil {
+			return err
+		}
+		channel = channelID
+	} else if opts.mdFile != "" {
+		if text != "" {
+			return fmt.Errorf("cannot use message text and --markdown-file together")
+		}
+		md, err := readMarkdownFile(opts.mdFile, opts.stdin)
+		if err != nil {
+			return err
+		}
+		text = md
+	} else {
+		// Unescape shell-escaped characters (e.g., \! from zsh)
+		text = unescapeShellChars(text)
+	}
+
+	var blocks []interface{}
+	if opts.blocksJSON != "" {
+		var err error
+		if blocks, err = parseBlocks(opts.blocksJSON, opts.noValidate); err != nil {
+			return err
+		}
+	}
+
+	if text == "" && opts.blocksJSON == "" {
+		return fmt.Errorf("message text cannot be empty (or provide blocks via --blocks or Markdown via --markdown-file)")
+	}
 
 	if err := validateMessageLength(text, true); err != nil {
 		return err
@@ -61,19 +158,24 @@ func runUpdate(channel, timestamp, text string, opts *updateOptions, c *client.C
 		return err
 	}
 
-	var blocks []interface{}
-	if opts.blocksJSON != "" {
-		if err := json.Unmarshal([]byte(opts.blocksJSON), &blocks); err != nil {
-			return fmt.Errorf("invalid blocks JSON: %w", err)
+	switch {
+	case opts.blocksJSON != "":
+		// Parsed and validated above, before any API call.
+	case markdown:
+		if blocks, err = markdownBlocks(text); err != nil {
+			return err
 		}
-	} else if !opts.simple {
+	case !opts.simple:
 		// Default to block style for a more refined appearance
 		blocks = buildDefaultBlocks(text)
 	}
 
+	entry := c.MessageEntry(journal.ActionUpdate, channelID, timestamp)
 	if err := c.UpdateMessage(channelID, timestamp, text, blocks, !opts.noUnfurl); err != nil {
 		return err
 	}
+	entry.Text = text
+	c.Record(entry)
 
 	output.Println("Message updated")
 	return nil
@@ -0,0 +1,272 @@
+package messages
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+// Exit statuses of messages wait besides 0 (approved). The timeout status
+// matches timeout(1) so CI scripts can tell it apart from a rejection.
+const (
+	waitRejectedCode = 1
+	waitTimeoutCode  = 124
+)
+
+// waitError ends messages wait with a specific exit status.
+type waitError struct {
+	code int
+	msg  string
+}
+
+func (e *waitError) Error() string { return e.msg }
+
+// ExitCode is the process exit status the root command uses for this error.
+func (e *waitError) ExitCode() int { return e.code }
+
+type waitOptions struct {
+	reaction string
+	reject   string
+	from     []string
+	timeout  time.Duration
+	interval time.Duration
+	post     string
+	threadTS string
+}
+
+func newWaitCmd() *cobra.Command {
+	opts := &waitOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "wait {<ref> | <channel> <timestamp> | <channel> --post <text>}",
+		Short: "Wait for an approval reaction on a message",
+		Long: `Poll a message's reactions until someone approves or rejects it, for
+manual approval gates in CI.
+
+` + messageRefHelp + `
+
+With --post, the approval request is sent first (to the channel given as the
+only argument) and then waited on.
+
+Only reactions from the --from users and user groups count; without --from,
+anyone's reaction counts. The approver's name is printed. Exit status:
+  0    approved (--reaction added)
+  1    rejected (--reject added) or an error
+  124  --timeout reached without a decision
+
+Examples:
+  slck messages wait C1234567890/1234567890.123456 --from @releasemgrs --timeout 30m
+  slck messages wait releases --post "Deploy v2.3 to production?" --reject x --from @releasemgrs`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if opts.post != "" {
+				return cobra.ExactArgs(1)(cmd, args)
+			}
+			return messageArgs(0, 0)(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			if opts.post != "" {
+				return reportedOutcome(cmd, runWait(ctx, args[0], "", opts, nil))
+			}
+			channel, timestamp, _, err := splitMessageArgs(args)
+			if err != nil {
+				return err
+			}
+			return reportedOutcome(cmd, runWait(ctx, channel, timestamp, opts, nil))
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.reaction, "reaction", "white_check_mark", "Reaction that approves")
+	cmd.Flags().StringVar(&opts.reject, "reject", "", "Reaction that rejects (e.g. x)")
+	cmd.Flags().StringSliceVar(&opts.from, "from", nil, "Users or user groups whose reactions count (IDs or @handles, repeatable)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 30*time.Minute, "Give up after this long")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 10*time.Second, "Polling interval")
+	cmd.Flags().StringVar(&opts.post, "post", "", "Post this approval request to the channel first")
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "With --post: post the request as a reply in this thread")
+
+	return cmd
+}
+
+func runWait(ctx context.Context, channel, timestamp string, opts *waitOptions, c *client.Client) error {
+	reaction := validate.Emoji(opts.reaction)
+	reject := validate.Emoji(opts.reject)
+	if reaction == "" {
+		return fmt.Errorf("--reaction cannot be empty")
+	}
+	if reaction == reject {
+		return fmt.Errorf("--reaction and --reject must differ")
+	}
+	if opts.timeout <= 0 || opts.interval <= 0 {
+		return fmt.Errorf("--timeout and --interval must be positive")
+	}
+	if opts.threadTS != "" && opts.post == "" {
+		return fmt.Errorf("--thread requires --post")
+	}
+	if timestamp != "" {
+		if err := validate.Timestamp(timestamp); err != nil {
+			return err
+		}
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	approvers, err := resolveApprovers(c, opts.from)
+	if err != nil {
+		return err
+	}
+
+	channelID := channel
+	if opts.post != "" {
+		send := &sendOptions{threadTS: opts.threadTS}
+		if err := runSend(channel, opts.post, send, c); err != nil {
+			return err
+		}
+		channelID, timestamp = send.sent.ChannelID, send.sent.TS
+		if client.IsDryRunPlaceholder(timestamp) {
+			// Nothing was posted, so there is nothing to wait on
+			fmt.Fprintln(os.Stderr, "Dry run: not waiting for approval")
+			return nil
+		}
+	} else if channelID, err = c.ResolveMessageDestination(channel); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Waiting up to %s for :%s: on %s/%s\n", opts.timeout, reaction, channelID, timestamp)
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+	resolver := client.NewUserResolver(c)
+	for {
+		msg, err := c.GetReactions(channelID, timestamp)
+		switch {
+		case err == nil:
+			if reject != "" {
+				if user := firstReactor(msg, reject, approvers); user != "" {
+					output.Printf("Rejected by %s\n", resolver.Resolve(user))
+					return &waitError{code: waitRejectedCode, msg: "approval rejected"}
+				}
+			}
+			if user := firstReactor(msg, reaction, approvers); user != "" {
+				output.Printf("Approved by %s\n", resolver.Resolve(user))
+				return nil
+			}
+		case waitFatal(err):
+			return client.WrapError("get reactions", err)
+		default:
+			fmt.Fprintf(os.Stderr, "warning: poll failed, retrying: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				output.Printf("Timed out after %s\n", opts.timeout)
+				return &waitError{code: waitTimeoutCode, msg: fmt.Sprintf("timed out after %s waiting for approval", opts.timeout)}
+			}
+			return fmt.Errorf("interrupted while waiting for approval")
+		case <-time.After(opts.interval):
+		}
+	}
+}
+
+// reportedOutcome silences cobra for a rejection or timeout: runWait has
+// already printed the outcome, so only the exit status is left to report.
+func reportedOutcome(cmd *cobra.Command, err error) error {
+	var werr *waitError
+	if errors.As(err, &werr) {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+	}
+	return err
+}
+
+// waitFatal reports whether a failed poll cannot succeed on retry.
+func waitFatal(err error) bool {
+	return client.IsSlackError(err, "message_not_found") || tailFatal(err)
+}
+
+// resolveApprovers turns --from values into a set of user IDs. A handle is
+// matched against user group handles first, then users. A nil set means
+// anyone may approve.
+func resolveApprovers(c *client.Client, from []string) (map[string]bool, error) {
+	if len(from) == 0 {
+		return nil, nil
+	}
+
+	approvers := map[string]bool{}
+	var groups []client.UserGroup
+	groupsLoaded := false
+	for _, f := range from {
+		f = strings.TrimSpace(f)
+		if client.IsUserID(f) {
+			approvers[f] = true
+			continue
+		}
+
+		if !groupsLoaded {
+			var err error
+			groups, err = c.ListUserGroups()
+			// Without usergroups:read only individual users can be named
+			if err != nil && !client.IsSlackError(err, "missing_scope") {
+				return nil, client.WrapError("list user groups", err)
+			}
+			groupsLoaded = true
+		}
+		if g := findUserGroup(groups, f); g != nil {
+			for _, u := range g.Users {
+				approvers[u] = true
+			}
+			continue
+		}
+
+		id, err := c.ResolveUserID(f)
+		if err != nil {
+			return nil, err
+		}
+		approvers[id] = true
+	}
+	return approvers, nil
+}
+
+func findUserGroup(groups []client.UserGroup, ref string) *client.UserGroup {
+	handle := strings.TrimPrefix(ref, "@")
+	for i, g := range groups {
+		if g.ID == ref || strings.EqualFold(g.Handle, handle) {
+			return &groups[i]
+		}
+	}
+	return nil
+}
+
+// firstReactor returns the first user who added the reaction and may
+// decide, or "" if there is none.
+func firstReactor(msg *client.Message, name string, approvers map[string]bool) string {
+	for _, r := range msg.Reactions {
+		// Skin-tone variants (white_check_mark::skin-tone-2) count too
+		if r.Name != name && !strings.HasPrefix(r.Name, name+"::") {
+			continue
+		}
+		for _, u := range r.Users {
+			if approvers == nil || approvers[u] {
+				return u
+			}
+		}
+	}
+	return ""
+}
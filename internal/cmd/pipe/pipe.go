@@ -0,0 +1,298 @@
+package pipe
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+const (
+	// chunkBytes bounds the escaped text of one message, keeping it under
+	// the 4,000 characters Slack displays without truncating.
+	chunkBytes = 3500
+
+	// maxLineRunes splits longer lines; escaping at most quintuples them,
+	// so one piece always fits a chunk.
+	maxLineRunes = 600
+
+	// maxPipeAttempts bounds retries of a post that Slack rate-limited.
+	maxPipeAttempts = 5
+)
+
+type pipeOptions struct {
+	thread   string
+	title    string
+	file     string
+	interval time.Duration
+	rate     float64
+	stdin    io.Reader // For testing
+}
+
+// NewCmd creates the pipe command
+func NewCmd() *cobra.Command {
+	opts := &pipeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "pipe <channel>",
+		Short: "Stream stdin or a file into a thread",
+		Long: `Read lines continuously and post them to a thread as code blocks.
+
+Lines are batched into one message until it is full or --interval has passed
+since the first unsent line, and posts are spaced out to --rate per second,
+so fast output arrives as a few large messages instead of many small ones.
+
+Without --thread, a new thread is started with --title as its first message.
+--thread takes a timestamp, a ref (C1234567890/1234567890.123456) or a
+permalink.
+
+Examples:
+  make 2>&1 | slck pipe builds
+  tail -f deploy.log | slck pipe deploys --title "Deploy to production"
+  slck pipe incidents --thread C1234567890/1234567890.123456 --file notes.txt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+			return runPipe(ctx, args[0], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.thread, "thread", "", "Post into this thread (timestamp, ref or permalink)")
+	cmd.Flags().StringVar(&opts.title, "title", "", "First message of the new thread (default: when streaming started)")
+	cmd.Flags().StringVar(&opts.file, "file", "", "Read from this file instead of stdin")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 2*time.Second, "Longest time a line waits before it is posted")
+	cmd.Flags().Float64Var(&opts.rate, "rate", 1.0, "Maximum messages per second")
+
+	return cmd
+}
+
+func runPipe(ctx context.Context, channel string, opts *pipeOptions, c *client.Client) error {
+	if opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if opts.rate <= 0 {
+		return fmt.Errorf("--rate must be greater than 0")
+	}
+	if opts.title != "" && opts.thread != "" {
+		return fmt.Errorf("--title starts a new thread and cannot be used with --thread")
+	}
+
+	var thread messageref.Ref
+	if opts.thread != "" {
+		var err error
+		if thread, err = messageref.ParseThread(opts.thread); err != nil {
+			return err
+		}
+	}
+
+	in := opts.stdin
+	if in == nil {
+		in = os.Stdin
+	}
+	if opts.file != "" {
+		f, err := os.Open(opts.file)
+		if err != nil {
+			return fmt.Errorf("opening file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		in = f
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+	if thread.ChannelID != "" && thread.ChannelID != channelID {
+		return fmt.Errorf("--thread %s is in channel %s, not %s", opts.thread, thread.ChannelID, channelID)
+	}
+
+	limiter := client.NewRateLimiter(opts.rate)
+	defer limiter.Stop()
+
+	p := &piper{c: c, channelID: channelID, threadTS: thread.TS, title: opts.title, limiter: limiter.C}
+	lines, readErr := readLines(in)
+
+	var flushC <-chan time.Time
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				if err := p.flush(); err != nil {
+					return err
+				}
+				if err := <-readErr; err != nil {
+					return fmt.Errorf("reading input: %w", err)
+				}
+				p.summary()
+				return nil
+			}
+			if err := p.add(line); err != nil {
+				return err
+			}
+			if flushC == nil && len(p.batch) > 0 {
+				flushC = time.After(opts.interval)
+			}
+		case <-flushC:
+			flushC = nil
+			if err := p.flush(); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			if err := p.drain(lines); err != nil {
+				return err
+			}
+			if err := p.flush(); err != nil {
+				return err
+			}
+			p.summary()
+			return nil
+		}
+	}
+}
+
+// readLines scans in on its own goroutine so a slow post never stalls the
+// writer on the other end of the pipe for long. The error channel receives
+// the scan result once lines is closed.
+func readLines(in io.Reader) (<-chan string, <-chan error) {
+	lines := make(chan string, 1024)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		errc <- scanner.Err()
+	}()
+	return lines, errc
+}
+
+// piper batches lines into code-block messages in one thread.
+type piper struct {
+	c         *client.Client
+	channelID string
+	threadTS  string
+	title     string
+	limiter   <-chan time.Time
+
+	batch  []string
+	size   int
+	posted int
+	sent   int
+}
+
+func (p *piper) add(line string) error {
+	for _, piece := range splitLine(strings.TrimRight(line, "\r")) {
+		n := len(client.EscapeMrkdwn(piece)) + 1
+		if p.size+n > chunkBytes {
+			if err := p.flush(); err != nil {
+				return err
+			}
+		}
+		p.batch = append(p.batch, piece)
+		p.size += n
+	}
+	return nil
+}
+
+// drain adds the lines already read without waiting for more, so an
+// interrupt does not lose output that arrived just before it.
+func (p *piper) drain(lines <-chan string) error {
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return nil
+			}
+			if err := p.add(line); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
+
+func (p *piper) flush() error {
+	if len(p.batch) == 0 {
+		return nil
+	}
+	if p.threadTS == "" {
+		title := p.title
+		if title == "" {
+			title = "Output streamed by slck pipe, started " + time.Now().Format("2006-01-02 15:04")
+		}
+		ts, err := p.post(title, "")
+		if err != nil {
+			return client.WrapError("start thread", err)
+		}
+		p.threadTS = ts
+		output.Printf("Streaming into %s/%s\n", p.channelID, ts)
+	}
+
+	if _, err := p.post(client.CodeBlock(strings.Join(p.batch, "\n")), p.threadTS); err != nil {
+		return client.WrapError("post output", err)
+	}
+	p.posted++
+	p.sent += len(p.batch)
+	p.batch, p.size = nil, 0
+	return nil
+}
+
+// post sends one message, waiting on the limiter before every attempt and
+// retrying when Slack rate-limits the call.
+func (p *piper) post(text, threadTS string) (string, error) {
+	var ts string
+	err := client.WithRetry(maxPipeAttempts, p.limiter, func() error {
+		msg, err := p.c.SendMessage(p.channelID, text, threadTS, nil, false)
+		if err == nil {
+			ts = msg.TS
+		}
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	p.c.Record(journal.Entry{Action: journal.ActionSend, Channel: p.channelID, TS: ts, Text: text})
+	return ts, nil
+}
+
+func (p *piper) summary() {
+	output.Printf("Posted %d lines in %d messages\n", p.sent, p.posted)
+}
+
+// splitLine cuts a line into pieces of at most maxLineRunes runes.
+func splitLine(line string) []string {
+	if utf8.RuneCountInString(line) <= maxLineRunes {
+		return []string{line}
+	}
+	var pieces []string
+	runes := []rune(line)
+	for len(runes) > maxLineRunes {
+		pieces = append(pieces, string(runes[:maxLineRunes]))
+		runes = runes[maxLineRunes:]
+	}
+	return append(pieces, string(runes))
+}
@@ -0,0 +1,152 @@
+package pipe
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+// postLog records chat.postMessage bodies; the server answers with
+// sequential timestamps.
+type postLog struct {
+	mu    sync.Mutex
+	posts []map[string]interface{}
+}
+
+func (l *postLog) snapshot() []map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]map[string]interface{}(nil), l.posts...)
+}
+
+func (l *postLog) start(t *testing.T) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if r.URL.Path != "/chat.postMessage" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		l.posts = append(l.posts, body)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "ts": fmt.Sprintf("1700000000.%06d", len(l.posts))})
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func quietOutput(t *testing.T) {
+	t.Helper()
+	orig := output.Writer
+	output.Writer = io.Discard
+	t.Cleanup(func() { output.Writer = orig })
+}
+
+func TestRunPipe_StartsThreadAndBatches(t *testing.T) {
+	quietOutput(t)
+	l := &postLog{}
+	c := l.start(t)
+
+	var in strings.Builder
+	for i := 0; i < 100; i++ {
+		fmt.Fprintf(&in, "line %02d %s\n", i, strings.Repeat("x", 50))
+	}
+	opts := &pipeOptions{title: "Build 42", interval: time.Hour, rate: 1000, stdin: strings.NewReader(in.String())}
+	require.NoError(t, runPipe(context.Background(), "C1234567890", opts, c))
+
+	posts := l.snapshot()
+	require.Len(t, posts, 3, "the thread parent, then two full code blocks")
+	assert.Equal(t, "Build 42", posts[0]["text"])
+	assert.Nil(t, posts[0]["thread_ts"])
+
+	var got []string
+	for _, p := range posts[1:] {
+		assert.Equal(t, "1700000000.000001", p["thread_ts"])
+		text := p["text"].(string)
+		assert.LessOrEqual(t, len(text), chunkBytes+8)
+		require.True(t, strings.HasPrefix(text, "```\n") && strings.HasSuffix(text, "\n```"))
+		got = append(got, strings.Split(strings.TrimSuffix(strings.TrimPrefix(text, "```\n"), "\n```"), "\n")...)
+	}
+	assert.Len(t, got, 100, "every line is posted once, in order")
+	assert.True(t, strings.HasPrefix(got[99], "line 99"))
+}
+
+func TestRunPipe_FlushesOnInterval(t *testing.T) {
+	quietOutput(t)
+	l := &postLog{}
+	c := l.start(t)
+
+	r, w := io.Pipe()
+	opts := &pipeOptions{thread: "C1234567890/1690000000.000001", interval: 10 * time.Millisecond, rate: 1000, stdin: r}
+	done := make(chan error, 1)
+	go func() { done <- runPipe(context.Background(), "C1234567890", opts, c) }()
+
+	_, _ = io.WriteString(w, "first <tag>\n")
+	require.Eventually(t, func() bool {
+		return len(l.snapshot()) == 1
+	}, time.Second, 5*time.Millisecond, "the line is posted without waiting for EOF")
+	_, _ = io.WriteString(w, "second\n")
+	require.NoError(t, w.Close())
+	require.NoError(t, <-done)
+
+	posts := l.snapshot()
+	require.Len(t, posts, 2, "no new thread when --thread is given")
+	assert.Equal(t, "```\nfirst &lt;tag&gt;\n```", posts[0]["text"])
+	assert.Equal(t, "1690000000.000001", posts[0]["thread_ts"])
+	assert.Equal(t, "```\nsecond\n```", posts[1]["text"])
+}
+
+func TestPiper_DrainTakesOnlyBufferedLines(t *testing.T) {
+	lines := make(chan string, 3)
+	lines <- "one"
+	lines <- "two"
+	p := &piper{}
+
+	require.NoError(t, p.drain(lines), "returns with the channel still open")
+	assert.Equal(t, []string{"one", "two"}, p.batch)
+
+	lines <- "three"
+	close(lines)
+	require.NoError(t, p.drain(lines))
+	assert.Equal(t, []string{"one", "two", "three"}, p.batch)
+}
+
+func TestRunPipe_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts pipeOptions
+		want string
+	}{
+		{"bad interval", pipeOptions{rate: 1}, "--interval must be positive"},
+		{"bad rate", pipeOptions{interval: time.Second}, "--rate must be greater than 0"},
+		{"title with thread", pipeOptions{interval: time.Second, rate: 1, title: "x", thread: "1700000000.000001"}, "cannot be used with --thread"},
+		{"bad thread", pipeOptions{interval: time.Second, rate: 1, thread: "yesterday"}, "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runPipe(context.Background(), "C1234567890", &tt.opts, nil)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestSplitLine(t *testing.T) {
+	pieces := splitLine(strings.Repeat("é", maxLineRunes*2+1))
+	require.Len(t, pieces, 3)
+	assert.Equal(t, "é", pieces[2])
+	assert.Equal(t, []string{""}, splitLine(""))
+}
@@ -0,0 +1,97 @@
+package poll
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+	"github.com/open-cli-collective/slack-chat-api/internal/validate"
+)
+
+type createOptions struct {
+	options  []string
+	threadTS string
+}
+
+func newCreateCmd() *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create <channel> <question>",
+		Short: "Post a poll and seed its reactions",
+		Long: `Post a poll with numbered options and add one reaction per option, so
+voters only have to click.
+
+Between 2 and 10 options are allowed. Read the votes back with
+'slck poll results' using the ref this command prints.
+
+Examples:
+  slck poll create team "Lunch on Friday?" --option Pizza --option Tacos
+  slck poll create C1234567890 "Ship 2.0 today?" --option Yes --option No --option "Wait a day"`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(args[0], args[1], opts, nil)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&opts.options, "option", nil, "Poll option (repeat for each option)")
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "Post the poll as a reply in this thread")
+
+	return cmd
+}
+
+func runCreate(channel, question string, opts *createOptions, c *client.Client) error {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return fmt.Errorf("question cannot be empty")
+	}
+	if len(opts.options) < 2 || len(opts.options) > len(optionEmoji) {
+		return fmt.Errorf("a poll needs between 2 and %d --option flags, got %d", len(optionEmoji), len(opts.options))
+	}
+	options := make([]string, len(opts.options))
+	for i, opt := range opts.options {
+		options[i] = strings.TrimSpace(opt)
+		if options[i] == "" || strings.Contains(options[i], "\n") {
+			return fmt.Errorf("option %d must be a single non-empty line", i+1)
+		}
+	}
+	if opts.threadTS != "" {
+		if err := validate.Timestamp(opts.threadTS); err != nil {
+			return err
+		}
+		opts.threadTS = validate.NormalizeTimestamp(opts.threadTS)
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(channel)
+	if err != nil {
+		return err
+	}
+
+	text := formatPoll(question, options)
+	msg, err := c.SendMessage(channelID, text, opts.threadTS, nil, false)
+	if err != nil {
+		return client.WrapError("send poll", err)
+	}
+	c.Record(journal.Entry{Action: journal.ActionSend, Channel: channelID, TS: msg.TS, Text: text})
+
+	for i := range options {
+		if err := c.AddReaction(channelID, msg.TS, optionEmoji[i]); err != nil {
+			return client.WrapError(fmt.Sprintf("poll posted (ts %s) but seeding :%s:", msg.TS, optionEmoji[i]), err)
+		}
+	}
+
+	output.Printf("Poll posted: %s/%s\n", channelID, msg.TS)
+	return nil
+}
@@ -0,0 +1,63 @@
+package poll
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// optionEmoji are the reactions seeded for each option, in order. Slack has
+// no :ten: alias, so the tenth option uses the keycap name.
+var optionEmoji = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "keycap_ten"}
+
+// optionLine matches one option line of a posted poll: ":two: Option text".
+var optionLine = regexp.MustCompile(`^:([a-z_]+): (.+)$`)
+
+// NewCmd creates the poll command with all subcommands
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "poll",
+		Short: "Run reaction-based polls",
+	}
+
+	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newResultsCmd())
+
+	return cmd
+}
+
+// pollOption is one numbered option of a poll.
+type pollOption struct {
+	emoji string
+	text  string
+}
+
+// formatPoll renders the poll message: the question in bold, then one
+// line per option prefixed with its number emoji.
+func formatPoll(question string, options []string) string {
+	var b strings.Builder
+	b.WriteString(":bar_chart: *" + client.EscapeMrkdwn(question) + "*\n")
+	for i, opt := range options {
+		b.WriteString("\n:" + optionEmoji[i] + ": " + client.EscapeMrkdwn(opt))
+	}
+	return b.String()
+}
+
+var unescapeMrkdwn = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+
+// parsePoll recovers the options from a poll message's text. It returns
+// nil when the text has no option lines, i.e. it is not a poll.
+func parsePoll(text string) []pollOption {
+	var options []pollOption
+	for _, line := range strings.Split(text, "\n") {
+		m := optionLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || len(options) == len(optionEmoji) || m[1] != optionEmoji[len(options)] {
+			continue
+		}
+		options = append(options, pollOption{emoji: m[1], text: unescapeMrkdwn.Replace(m[2])})
+	}
+	return options
+}
@@ -0,0 +1,149 @@
+package poll
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	var buf strings.Builder
+	origWriter := output.Writer
+	output.Writer = &buf
+	defer func() { output.Writer = origWriter }()
+	fn()
+	return buf.String()
+}
+
+func TestFormatAndParsePoll(t *testing.T) {
+	text := formatPoll("Deploy <today>?", []string{"Yes", "No & wait"})
+	assert.Equal(t, ":bar_chart: *Deploy &lt;today&gt;?*\n\n:one: Yes\n:two: No &amp; wait", text)
+
+	assert.Equal(t, []pollOption{{"one", "Yes"}, {"two", "No & wait"}}, parsePoll(text))
+	assert.Nil(t, parsePoll("just a message :one: with emoji"))
+}
+
+func TestRunCreate_PostsAndSeedsReactions(t *testing.T) {
+	var posted map[string]interface{}
+	var seeded []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			posted = body
+			resp["ts"] = "1700000000.000100"
+		case "/reactions.add":
+			assert.Equal(t, "1700000000.000100", body["timestamp"])
+			seeded = append(seeded, body["name"].(string))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	out := captureOutput(t, func() {
+		err := runCreate("C1234567890", "Lunch?", &createOptions{options: []string{"Pizza", "Tacos", "Salad"}, threadTS: "p1699999999000100"}, c)
+		require.NoError(t, err)
+	})
+
+	assert.Equal(t, "1699999999.000100", posted["thread_ts"])
+	assert.Equal(t, ":bar_chart: *Lunch?*\n\n:one: Pizza\n:two: Tacos\n:three: Salad", posted["text"])
+	assert.Equal(t, []string{"one", "two", "three"}, seeded)
+	assert.Equal(t, "Poll posted: C1234567890/1700000000.000100\n", out)
+}
+
+func TestRunCreate_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		question string
+		options  []string
+		thread   string
+		want     string
+	}{
+		{"empty question", " ", []string{"a", "b"}, "", "question cannot be empty"},
+		{"one option", "Q?", []string{"a"}, "", "between 2 and 10"},
+		{"too many options", "Q?", strings.Split("a b c d e f g h i j k", " "), "", "between 2 and 10"},
+		{"blank option", "Q?", []string{"a", " "}, "", "option 2 must be"},
+		{"invalid thread", "Q?", []string{"a", "b"}, "yesterday", "invalid timestamp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := runCreate("C1234567890", tt.question, &createOptions{options: tt.options, threadTS: tt.thread}, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRunResults_ExcludesSeedReactions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reactions.get":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok": true,
+				"message": map[string]interface{}{
+					"ts":   "1700000000.000100",
+					"user": "UBOT",
+					"text": formatPoll("Lunch?", []string{"Pizza", "Tacos"}),
+					"reactions": []map[string]interface{}{
+						{"name": "one", "count": 3, "users": []string{"UBOT", "U001", "U002"}},
+						{"name": "two", "count": 1, "users": []string{"UBOT"}},
+						{"name": "eyes", "count": 1, "users": []string{"U003"}},
+					},
+				},
+			})
+		case "/users.info":
+			names := map[string]string{"U001": "alice", "U002": "bob"}
+			id := r.URL.Query().Get("user")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"ok":   true,
+				"user": map[string]interface{}{"id": id, "name": names[id], "profile": map[string]interface{}{"display_name": names[id]}},
+			})
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	out := captureOutput(t, func() {
+		require.NoError(t, runResults("C1234567890/1700000000.000100", c))
+	})
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 5)
+	assert.Contains(t, lines[2], ":one: Pizza")
+	assert.Contains(t, lines[2], "alice, bob")
+	assert.Regexp(t, `:two: Tacos\s+0`, lines[3])
+	assert.NotContains(t, out, "UBOT")
+	assert.NotContains(t, out, "eyes")
+	assert.Equal(t, "2 votes", lines[4])
+}
+
+func TestRunResults_NotAPoll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"ok":      true,
+			"message": map[string]interface{}{"ts": "1700000000.000100", "text": "hello"},
+		})
+	}))
+	defer server.Close()
+
+	c := client.NewWithConfig(server.URL, "test-token", nil)
+	err := runResults("C1234567890/1700000000.000100", c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a poll")
+}
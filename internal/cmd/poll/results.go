@@ -0,0 +1,81 @@
+package poll
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
+	"github.com/open-cli-collective/slack-chat-api/internal/output"
+)
+
+func newResultsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "results <ref>",
+		Short: "Tally the votes on a poll",
+		Long: `Count the votes on a poll posted by 'slck poll create', with the names of
+the voters for each option.
+
+The reactions seeded by the poll's author are not counted. <ref> is
+<channel_id>/<ts> as printed by 'slck poll create', or a Slack permalink.
+
+Examples:
+  slck poll results C1234567890/1234567890.123456
+  slck poll results https://example.slack.com/archives/C1234567890/p1234567890123456`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResults(args[0], nil)
+		},
+	}
+}
+
+func runResults(refArg string, c *client.Client) error {
+	ref, err := messageref.Parse(refArg)
+	if err != nil {
+		return err
+	}
+
+	if c == nil {
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	msg, err := c.GetReactions(ref.ChannelID, ref.TS)
+	if err != nil {
+		return client.WrapError("get poll", err)
+	}
+
+	options := parsePoll(msg.Text)
+	if len(options) == 0 {
+		return fmt.Errorf("message %s is not a poll", ref)
+	}
+
+	voters := map[string][]string{}
+	for _, r := range msg.Reactions {
+		for _, u := range r.Users {
+			if u != msg.User {
+				voters[r.Name] = append(voters[r.Name], u)
+			}
+		}
+	}
+
+	resolver := client.NewUserResolver(c)
+	rows := make([][]string, 0, len(options))
+	total := 0
+	for _, opt := range options {
+		names := make([]string, 0, len(voters[opt.emoji]))
+		for _, u := range voters[opt.emoji] {
+			names = append(names, resolver.Resolve(u))
+		}
+		total += len(names)
+		rows = append(rows, []string{":" + opt.emoji + ": " + opt.text, strconv.Itoa(len(names)), strings.Join(names, ", ")})
+	}
+	output.Table([]string{"OPTION", "VOTES", "VOTERS"}, rows)
+	output.Printf("%d votes\n", total)
+	return nil
+}
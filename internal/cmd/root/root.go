@@ -1,6 +1,7 @@
 package root
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,18 +10,26 @@ import (
 	"github.com/open-cli-collective/cli-common/credstore"
 
 	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/blocks"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/canvas"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/channels"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/config"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/emoji"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/export"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/files"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/importcmd"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/initcmd"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/journalcmd"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/me"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/messages"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/pipe"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/poll"
+	"github.com/open-cli-collective/slack-chat-api/internal/cmd/run"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/search"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/setcred"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/users"
 	"github.com/open-cli-collective/slack-chat-api/internal/cmd/workspace"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
 	"github.com/open-cli-collective/slack-chat-api/internal/keychain"
 	"github.com/open-cli-collective/slack-chat-api/internal/output"
 	"github.com/open-cli-collective/slack-chat-api/internal/version"
@@ -29,6 +38,7 @@ import (
 var outputFormat string
 var asUser bool
 var asBot bool
+var dryRun bool
 
 // backendFlag is the pflag storage anchor for --backend. It is
 // intentionally not read directly: WireBackendSelection consults the
@@ -74,6 +84,14 @@ config.yml so users on headless / non-default platforms can opt in.`,
 		if asUser {
 			client.SetAsUser(true)
 		}
+		// Changes are journaled for 'slck undo'; a dry run makes none
+		if dryRun {
+			client.SetDryRun(output.Writer)
+		} else if log, err := journal.Open(); err == nil {
+			client.SetJournal(log)
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: changes will not be journaled: %v\n", err)
+		}
 
 		return WireBackendSelection(cmd)
 	},
@@ -120,8 +138,16 @@ func Command() *cobra.Command { return rootCmd }
 
 // Execute runs the root command
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	if cmd, err := rootCmd.ExecuteC(); err != nil {
+		// A command that silences errors has reported the outcome itself
+		if !cmd.SilenceErrors {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		// Some commands (messages wait) report outcomes through the exit status
+		var coded interface{ ExitCode() int }
+		if errors.As(err, &coded) {
+			os.Exit(coded.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
@@ -131,6 +157,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&output.NoColor, "no-color", false, "Disable colored output")
 	rootCmd.PersistentFlags().BoolVar(&asUser, "as-user", false, "Use user token")
 	rootCmd.PersistentFlags().BoolVar(&asBot, "as-bot", false, "Use bot token")
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "Print write API calls (method and payload) instead of sending them")
 	rootCmd.PersistentFlags().StringVar(&backendFlag, credstore.BackendFlagName, "", credstore.BackendFlagUsage())
 
 	// Set custom version template to include commit and build date
@@ -141,12 +168,20 @@ func init() {
 	rootCmd.AddCommand(channels.NewCmd())
 	rootCmd.AddCommand(users.NewCmd())
 	rootCmd.AddCommand(messages.NewCmd())
+	rootCmd.AddCommand(poll.NewCmd())
 	rootCmd.AddCommand(search.NewCmd())
 	rootCmd.AddCommand(workspace.NewCmd())
 	rootCmd.AddCommand(me.NewCmd())
 	rootCmd.AddCommand(config.NewCmd())
 	rootCmd.AddCommand(emoji.NewCmd())
 	rootCmd.AddCommand(files.NewCmd())
+	rootCmd.AddCommand(blocks.NewCmd())
+	rootCmd.AddCommand(export.NewCmd())
+	rootCmd.AddCommand(importcmd.NewCmd())
+	rootCmd.AddCommand(run.NewCmd())
+	rootCmd.AddCommand(pipe.NewCmd())
+	rootCmd.AddCommand(journalcmd.NewCmd())
+	rootCmd.AddCommand(journalcmd.NewUndoCmd())
 	rootCmd.AddCommand(initcmd.NewCmd())
 	rootCmd.AddCommand(setcred.NewCmd())
 }
@@ -0,0 +1,243 @@
+package run
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+	"github.com/open-cli-collective/slack-chat-api/internal/messageref"
+)
+
+// exitError carries the wrapped command's exit status to the root command.
+type exitError struct {
+	name string
+	code int
+}
+
+func (e *exitError) Error() string {
+	return fmt.Sprintf("%s exited with code %d", e.name, e.code)
+}
+
+// ExitCode is the process exit status the root command uses for this error.
+func (e *exitError) ExitCode() int { return e.code }
+
+type runOptions struct {
+	channel  string
+	threadTS string
+	interval time.Duration
+	lines    int
+	stdout   io.Writer // For testing
+	stderr   io.Writer // For testing
+}
+
+// NewCmd creates the run command
+func NewCmd() *cobra.Command {
+	opts := &runOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "run --channel <channel> -- <command> [args...]",
+		Short: "Run a command and report its progress to Slack",
+		Long: `Run a command, posting a status message that is updated in place with the
+elapsed time and the last lines of output.
+
+When the command exits the message shows the outcome, duration and exit
+code; on failure the full output is attached as a file in the message's
+thread. The command's output is passed through unchanged and slck exits with
+the command's exit code.
+
+Examples:
+  slck run --channel deploys -- ./deploy.sh production
+  slck run --channel ci --interval 30s --lines 10 -- make test`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRun(cmd.Context(), args, opts, nil)
+		},
+	}
+	// Everything after the command name belongs to the command
+	cmd.Flags().SetInterspersed(false)
+
+	cmd.Flags().StringVar(&opts.channel, "channel", "", "Channel to report to (required)")
+	cmd.Flags().StringVar(&opts.threadTS, "thread", "", "Post the status message as a reply in this thread (timestamp, ref or permalink)")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 15*time.Second, "How often to update the status message")
+	cmd.Flags().IntVar(&opts.lines, "lines", 5, "Number of recent output lines to show")
+	_ = cmd.MarkFlagRequired("channel")
+
+	return cmd
+}
+
+func runRun(ctx context.Context, argv []string, opts *runOptions, c *client.Client) error {
+	if opts.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if opts.lines < 0 {
+		return fmt.Errorf("--lines cannot be negative")
+	}
+	var thread messageref.Ref
+	if opts.threadTS != "" {
+		var err error
+		if thread, err = messageref.ParseThread(opts.threadTS); err != nil {
+			return err
+		}
+	}
+	if _, err := exec.LookPath(argv[0]); err != nil {
+		return err
+	}
+	stdout, stderr := opts.stdout, opts.stderr
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	if c == nil {
+		var err error
+		c, err = client.New()
+		if err != nil {
+			return err
+		}
+	}
+
+	channelID, err := c.ResolveMessageDestination(opts.channel)
+	if err != nil {
+		return err
+	}
+	if thread.ChannelID != "" && thread.ChannelID != channelID {
+		return fmt.Errorf("--thread %s is in channel %s, not %s", opts.threadTS, thread.ChannelID, channelID)
+	}
+
+	logFile, err := os.CreateTemp("", "slck-run-*.log")
+	if err != nil {
+		return fmt.Errorf("creating log file: %w", err)
+	}
+	defer func() {
+		_ = logFile.Close()
+		_ = os.Remove(logFile.Name())
+	}()
+	rec := newRecorder(logFile, opts.lines)
+
+	name := strings.Join(argv, " ")
+	start := time.Now()
+	// status is the text the status message shows, kept so the final
+	// update is journaled with what it replaced
+	status := runningText(name, 0, nil)
+	msg, err := c.SendMessage(channelID, status, thread.TS, nil, false)
+	if err != nil {
+		return client.WrapError("post status message", err)
+	}
+	c.Record(journal.Entry{Action: journal.ActionSend, Channel: channelID, TS: msg.TS, Text: status})
+
+	child := exec.Command(argv[0], argv[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = io.MultiWriter(stdout, rec)
+	child.Stderr = io.MultiWriter(stderr, rec)
+	if err := child.Start(); err != nil {
+		finish(c, channelID, msg.TS, status, finishedText(name, 0, -1, []string{err.Error()}), stderr)
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- child.Wait() }()
+
+	// Forward interrupts so the command can clean up; its exit is reported as usual
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+	ctxDone := ctx.Done()
+	var waitErr error
+loop:
+	for {
+		select {
+		case waitErr = <-done:
+			break loop
+		case sig := <-sigs:
+			_ = child.Process.Signal(sig)
+		case <-ctxDone:
+			_ = child.Process.Kill()
+			ctxDone = nil
+		case <-ticker.C:
+			text := runningText(name, time.Since(start), rec.tail())
+			if err := c.UpdateMessage(channelID, msg.TS, text, nil, false); err != nil {
+				fmt.Fprintf(stderr, "warning: status update failed: %v\n", client.WrapError("update status message", err))
+				continue
+			}
+			status = text
+		}
+	}
+
+	code := 0
+	var ee *exec.ExitError
+	switch {
+	case errors.As(waitErr, &ee):
+		// -1 means the command was killed by a signal
+		code = max(ee.ExitCode(), 1)
+	case waitErr != nil:
+		return waitErr
+	}
+
+	elapsed := time.Since(start)
+	finish(c, channelID, msg.TS, status, finishedText(name, elapsed, code, rec.tail()), stderr)
+
+	if code == 0 {
+		return nil
+	}
+
+	threadTS := thread.TS
+	if threadTS == "" {
+		threadTS = msg.TS
+	}
+	if err := uploadLog(c, logFile, filepath.Base(argv[0])+".log", channelID, threadTS); err != nil {
+		fmt.Fprintf(stderr, "warning: log upload failed: %v\n", client.WrapError("upload log", err))
+	}
+	return &exitError{name: filepath.Base(argv[0]), code: code}
+}
+
+// finish writes the final status over previous. A failure only warns: the
+// command has already run, and its exit code matters more than the report.
+// Only this update is journaled, not the progress updates before it.
+func finish(c *client.Client, channelID, ts, previous, text string, stderr io.Writer) {
+	if err := c.UpdateMessage(channelID, ts, text, nil, false); err != nil {
+		fmt.Fprintf(stderr, "warning: final status update failed: %v\n", client.WrapError("update status message", err))
+		return
+	}
+	c.Record(journal.Entry{
+		Action: journal.ActionUpdate, Channel: channelID, TS: ts, Text: text,
+		Previous: previous, HasPrevious: true,
+	})
+}
+
+func uploadLog(c *client.Client, logFile *os.File, name, channelID, threadTS string) error {
+	info, err := logFile.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() == 0 {
+		return nil
+	}
+	if _, err := logFile.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	id, err := c.UploadFile(client.FileUpload{Name: name, Content: logFile, Size: info.Size()}, channelID, threadTS, "Full output")
+	if err != nil {
+		return err
+	}
+	c.Record(journal.Entry{Action: journal.ActionUpload, Channel: channelID, Files: []string{id}, Text: "Full output"})
+	return nil
+}
@@ -0,0 +1,187 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+	"github.com/open-cli-collective/slack-chat-api/internal/journal"
+)
+
+// TestHelperProcess is the command wrapped by the tests below, not a real
+// test. It prints its arguments as lines, then exits with SLCK_HELPER_EXIT.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("SLCK_HELPER_PROCESS") != "1" {
+		return
+	}
+	args := os.Args
+	for i, a := range args {
+		if a == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	for _, a := range args {
+		fmt.Println(a)
+	}
+	time.Sleep(50 * time.Millisecond)
+	code, _ := strconv.Atoi(os.Getenv("SLCK_HELPER_EXIT"))
+	os.Exit(code)
+}
+
+func helperArgv(t *testing.T, exit int, lines ...string) []string {
+	t.Setenv("SLCK_HELPER_PROCESS", "1")
+	t.Setenv("SLCK_HELPER_EXIT", strconv.Itoa(exit))
+	return append([]string{os.Args[0], "-test.run=TestHelperProcess", "--"}, lines...)
+}
+
+// runServer records status posts, updates and completed uploads.
+type runServer struct {
+	mu        sync.Mutex
+	posts     []map[string]interface{}
+	updates   []string
+	uploaded  string
+	completes []map[string]interface{}
+}
+
+func (s *runServer) start(t *testing.T) *client.Client {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if r.URL.Path == "/upload" {
+			data, _ := io.ReadAll(r.Body)
+			s.uploaded = string(data)
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		resp := map[string]interface{}{"ok": true}
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			s.posts = append(s.posts, body)
+			resp["ts"] = "1700000000.000100"
+		case "/chat.update":
+			s.updates = append(s.updates, body["text"].(string))
+		case "/files.getUploadURLExternal":
+			resp["upload_url"] = server.URL + "/upload"
+			resp["file_id"] = "F1"
+		case "/files.completeUploadExternal":
+			s.completes = append(s.completes, body)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+	return client.NewWithConfig(server.URL, "test-token", nil)
+}
+
+func TestRunRun_Success(t *testing.T) {
+	s := &runServer{}
+	c := s.start(t)
+	var stdout bytes.Buffer
+
+	opts := &runOptions{channel: "C1234567890", interval: 10 * time.Millisecond, lines: 2, stdout: &stdout, stderr: io.Discard}
+	err := runRun(context.Background(), helperArgv(t, 0, "one", "two", "three"), opts, c)
+	require.NoError(t, err)
+
+	assert.Equal(t, "one\ntwo\nthree\n", stdout.String(), "output is passed through")
+	require.Len(t, s.posts, 1)
+	assert.Contains(t, s.posts[0]["text"], ":hourglass_flowing_sand: Running")
+
+	require.GreaterOrEqual(t, len(s.updates), 2, "progress updates, then the final status")
+	assert.Contains(t, s.updates[0], "elapsed")
+	final := s.updates[len(s.updates)-1]
+	assert.Contains(t, final, ":white_check_mark:")
+	assert.Contains(t, final, "(exit code 0)")
+	assert.Contains(t, final, "```\ntwo\nthree\n```")
+	assert.Empty(t, s.completes, "no log upload on success")
+}
+
+func TestRunRun_FailureUploadsLogAndPropagatesCode(t *testing.T) {
+	log := journal.New(filepath.Join(t.TempDir(), "journal.jsonl"))
+	client.SetJournal(log)
+	defer client.SetJournal(nil)
+
+	s := &runServer{}
+	c := s.start(t)
+
+	opts := &runOptions{channel: "C1234567890", interval: time.Minute, lines: 5, stdout: io.Discard, stderr: io.Discard}
+	err := runRun(context.Background(), helperArgv(t, 3, "boom"), opts, c)
+
+	var ee *exitError
+	require.ErrorAs(t, err, &ee)
+	assert.Equal(t, 3, ee.ExitCode())
+
+	require.Len(t, s.updates, 1)
+	assert.Contains(t, s.updates[0], ":x:")
+	assert.Contains(t, s.updates[0], "(exit code 3)")
+	assert.Equal(t, "boom\n", s.uploaded)
+	require.Len(t, s.completes, 1)
+	assert.Equal(t, "1700000000.000100", s.completes[0]["thread_ts"], "log goes into the status message's thread")
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, journal.ActionSend, entries[0].Action)
+	assert.Equal(t, journal.ActionUpdate, entries[1].Action)
+	assert.Equal(t, entries[0].Text, entries[1].Previous, "the final update replaced the running status")
+	assert.Equal(t, journal.ActionUpload, entries[2].Action)
+	assert.Equal(t, []string{"F1"}, entries[2].Files)
+}
+
+func TestRunRun_ThreadRef(t *testing.T) {
+	s := &runServer{}
+	c := s.start(t)
+	opts := &runOptions{
+		channel: "C1234567890", threadTS: "https://example.slack.com/archives/C1234567890/p1699999999000100",
+		interval: time.Minute, lines: 5, stdout: io.Discard, stderr: io.Discard,
+	}
+	require.NoError(t, runRun(context.Background(), helperArgv(t, 0, "ok"), opts, c))
+	require.Len(t, s.posts, 1)
+	assert.Equal(t, "1699999999.000100", s.posts[0]["thread_ts"])
+
+	// A thread in another channel is refused before the command starts
+	marker := filepath.Join(t.TempDir(), "ran")
+	opts.threadTS = "C0000000002/1699999999.000100"
+	err := runRun(context.Background(), []string{"touch", marker}, opts, c)
+	assert.ErrorContains(t, err, "--thread C0000000002/1699999999.000100 is in channel C0000000002, not C1234567890")
+	assert.NoFileExists(t, marker)
+	assert.Len(t, s.posts, 1)
+
+	opts.threadTS = "not-a-ts"
+	assert.Error(t, runRun(context.Background(), []string{"touch", marker}, opts, c))
+}
+
+func TestRecorder_Tail(t *testing.T) {
+	var log bytes.Buffer
+	r := newRecorder(&log, 2)
+	_, _ = r.Write([]byte("a\nb\n\nc"))
+	assert.Equal(t, []string{"b", "c"}, r.tail(), "the unterminated line counts, blank lines do not")
+	_, _ = r.Write([]byte("ontinued\n"))
+	assert.Equal(t, []string{"b", "continued"}, r.tail())
+	assert.Equal(t, "a\nb\n\ncontinued\n", log.String())
+}
+
+func TestRunningAndFinishedText(t *testing.T) {
+	assert.Equal(t, ":hourglass_flowing_sand: Running `make &lt;all&gt;`", runningText("make <all>", 0, nil))
+	assert.Equal(t, ":hourglass_flowing_sand: Running `make` — 1m5s elapsed\n```\nx '''\n```",
+		runningText("make", 65*time.Second+300*time.Millisecond, []string{"x ```"}))
+	assert.Equal(t, ":x: `make` failed after 2s (exit code 2)", finishedText("make", 2*time.Second, 2, nil))
+}
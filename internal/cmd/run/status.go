@@ -0,0 +1,110 @@
+package run
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/client"
+)
+
+// maxLineRunes bounds each output line shown in the status message.
+const maxLineRunes = 200
+
+// recorder writes the command's combined output to the log and keeps its
+// last lines for the status message. stdout and stderr are copied from
+// separate goroutines, hence the lock.
+type recorder struct {
+	mu      sync.Mutex
+	log     io.Writer
+	max     int
+	lines   []string
+	partial []byte
+}
+
+func newRecorder(log io.Writer, lines int) *recorder {
+	return &recorder{log: log, max: lines}
+}
+
+func (r *recorder) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.log.Write(p); err != nil {
+		return 0, err
+	}
+
+	r.partial = append(r.partial, p...)
+	for {
+		i := bytes.IndexByte(r.partial, '\n')
+		if i < 0 {
+			break
+		}
+		r.push(string(r.partial[:i]))
+		r.partial = r.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+func (r *recorder) push(line string) {
+	line = strings.TrimRight(line, "\r")
+	if strings.TrimSpace(line) == "" || r.max == 0 {
+		return
+	}
+	if utf8.RuneCountInString(line) > maxLineRunes {
+		line = string([]rune(line)[:maxLineRunes]) + "…"
+	}
+	r.lines = append(r.lines, line)
+	if len(r.lines) > r.max {
+		r.lines = r.lines[len(r.lines)-r.max:]
+	}
+}
+
+// tail returns the last complete lines plus any unterminated one.
+func (r *recorder) tail() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := append([]string(nil), r.lines...)
+	if len(r.partial) > 0 && r.max > 0 && strings.TrimSpace(string(r.partial)) != "" {
+		lines = append(lines, string(r.partial))
+		if len(lines) > r.max {
+			lines = lines[1:]
+		}
+	}
+	return lines
+}
+
+func runningText(name string, elapsed time.Duration, lines []string) string {
+	text := fmt.Sprintf(":hourglass_flowing_sand: Running `%s`", client.EscapeMrkdwn(name))
+	if elapsed > 0 {
+		text += " — " + elapsed.Round(time.Second).String() + " elapsed"
+	}
+	return text + codeBlock(lines)
+}
+
+// finishedText reports the outcome; code -1 means the command never started.
+func finishedText(name string, elapsed time.Duration, code int, lines []string) string {
+	name = client.EscapeMrkdwn(name)
+	var text string
+	switch {
+	case code == 0:
+		text = fmt.Sprintf(":white_check_mark: `%s` succeeded in %s (exit code 0)", name, elapsed.Round(time.Second))
+	case code < 0:
+		text = fmt.Sprintf(":x: `%s` could not be started", name)
+	default:
+		text = fmt.Sprintf(":x: `%s` failed after %s (exit code %d)", name, elapsed.Round(time.Second), code)
+	}
+	return text + codeBlock(lines)
+}
+
+func codeBlock(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n" + client.CodeBlock(strings.Join(lines, "\n"))
+}
@@ -76,6 +76,13 @@ func dirEnsured() (string, error) {
 	return configScope.ConfigDirEnsured()
 }
 
+// StateDir resolves the state directory WITHOUT creating it. It holds
+// machine-written records such as the action journal — never configuration
+// or secrets. Delegated to cli-common/statedir; native per OS.
+func StateDir() (string, error) {
+	return configScope.StateDir()
+}
+
 // Path is the config.yml location (resolved but not created).
 func Path() (string, error) {
 	dir, err := Dir()
@@ -0,0 +1,212 @@
+// Package journal keeps slck's local audit log: one JSON line per successful
+// mutating API call, appended to journal.jsonl in the state directory, with
+// enough of the previous state to reverse the call with `slck undo`. The log
+// is append-only; an undo is itself an entry pointing at the one it undid.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/open-cli-collective/slack-chat-api/internal/config"
+)
+
+const fileName = "journal.jsonl"
+
+// The journal is shared by every slck process, so appends take a lock file
+// next to it. A lock older than lockStale was left by a process that died
+// and is taken over.
+const (
+	lockRetry   = 10 * time.Millisecond
+	lockTimeout = 15 * time.Second
+	lockStale   = 10 * time.Second
+)
+
+// Actions recorded in the journal.
+const (
+	ActionSend      = "send"
+	ActionUpdate    = "update"
+	ActionDelete    = "delete"
+	ActionReact     = "react"
+	ActionUnreact   = "unreact"
+	ActionTopic     = "topic"
+	ActionPurpose   = "purpose"
+	ActionArchive   = "archive"
+	ActionUnarchive = "unarchive"
+	ActionInvite    = "invite"
+	ActionUpload    = "upload"
+)
+
+// Entry is one recorded action. Previous and PreviousBlocks hold what the
+// action replaced (a message's text and blocks, a topic or purpose) so it
+// can be restored; HasPrevious tells an empty previous value from one that
+// could not be looked up.
+type Entry struct {
+	ID             int           `json:"id"`
+	Time           time.Time     `json:"time"`
+	Token          string        `json:"token,omitempty"` // "bot" or "user"
+	Action         string        `json:"action"`
+	Channel        string        `json:"channel"`
+	TS             string        `json:"ts,omitempty"`
+	Reaction       string        `json:"reaction,omitempty"`
+	Users          []string      `json:"users,omitempty"`
+	Files          []string      `json:"files,omitempty"`
+	Text           string        `json:"text,omitempty"`
+	Previous       string        `json:"previous,omitempty"`
+	PreviousBlocks []interface{} `json:"previous_blocks,omitempty"`
+	HasPrevious    bool          `json:"has_previous,omitempty"`
+	UndoOf         int           `json:"undo_of,omitempty"`
+}
+
+// Log is the journal file. Appends are serialized within a process by mu
+// and across processes by the lock file; each continues from the last ID
+// on disk at the time.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Open returns the journal in slck's state directory. Nothing is created
+// until the first entry is appended.
+func Open() (*Log, error) {
+	dir, err := config.StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Join(dir, fileName)), nil
+}
+
+// New returns a journal stored at path.
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Path is the journal file's location.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append stamps e with the next ID (and the current time, when unset) and
+// writes it to the end of the journal.
+func (l *Log) Append(e Entry) (Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), config.DirPerm); err != nil {
+		return e, err
+	}
+	unlock, err := l.lock()
+	if err != nil {
+		return e, err
+	}
+	defer unlock()
+
+	entries, err := l.read()
+	if err != nil {
+		return e, err
+	}
+	e.ID = 1
+	if len(entries) > 0 {
+		e.ID = entries[len(entries)-1].ID + 1
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return e, err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, config.FilePerm)
+	if err != nil {
+		return e, err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return e, err
+	}
+	if err := f.Close(); err != nil {
+		return e, err
+	}
+	return e, nil
+}
+
+// lock creates the lock file, waiting while another process holds it, and
+// returns the function that releases it.
+func (l *Log) lock() (func(), error) {
+	path := l.path + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, config.FilePerm)
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > lockStale {
+			_ = os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("journal is locked by another slck process; remove %s if none is running", path)
+		}
+		time.Sleep(lockRetry)
+	}
+}
+
+// Entries returns every recorded entry, oldest first. A missing journal
+// has no entries.
+func (l *Log) Entries() ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.read()
+}
+
+func (l *Log) read() ([]Entry, error) {
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", l.path, n, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", l.path, err)
+	}
+	return entries, nil
+}
+
+// UndoneBy maps the ID of each undone entry to the ID of the entry that
+// undid it.
+func UndoneBy(entries []Entry) map[int]int {
+	undone := map[int]int{}
+	for _, e := range entries {
+		if e.UndoOf != 0 {
+			undone[e.UndoOf] = e.ID
+		}
+	}
+	return undone
+}
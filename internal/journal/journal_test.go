@@ -0,0 +1,88 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLog_AppendAndEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "journal.jsonl")
+	log := New(path)
+
+	entries, err := log.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a missing journal has no entries")
+
+	first, err := log.Append(Entry{Action: ActionSend, Channel: "C1", TS: "1.000001", Text: "hi"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.ID)
+	assert.False(t, first.Time.IsZero())
+
+	// A second process continues the numbering
+	second, err := New(path).Append(Entry{Action: ActionDelete, Channel: "C1", TS: "1.000001", UndoOf: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.ID)
+
+	entries, err = log.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "hi", entries[0].Text)
+	assert.Equal(t, map[int]int{1: 2}, UndoneBy(entries))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestLog_InterleavedLogsKeepIDsUnique(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	a, b := New(path), New(path)
+
+	// Each Log stands in for a separate process appending to the same file
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		for _, log := range []*Log{a, b} {
+			wg.Add(1)
+			go func(log *Log) {
+				defer wg.Done()
+				_, err := log.Append(Entry{Action: ActionReact, Channel: "C1"})
+				assert.NoError(t, err)
+			}(log)
+		}
+	}
+	wg.Wait()
+
+	entries, err := a.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 40)
+	for i, e := range entries {
+		assert.Equal(t, i+1, e.ID)
+	}
+	assert.NoFileExists(t, path+".lock", "the lock is released")
+}
+
+func TestLog_StaleLockTakenOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	require.NoError(t, os.WriteFile(path+".lock", nil, 0o600))
+	old := time.Now().Add(-2 * lockStale)
+	require.NoError(t, os.Chtimes(path+".lock", old, old))
+
+	e, err := New(path).Append(Entry{Action: ActionSend, Channel: "C1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, e.ID)
+}
+
+func TestLog_CorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"id":1,"action":"send"}`+"\nnot json\n"), 0o600))
+
+	_, err := New(path).Entries()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
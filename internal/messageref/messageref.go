@@ -81,6 +81,20 @@ func Parse(input string) (Ref, error) {
 	return Ref{ChannelID: channel, TS: ts}, nil
 }
 
+// ParseThread parses a --thread value: a bare timestamp, or a ref or
+// permalink as accepted by Parse. A bare timestamp leaves ChannelID empty;
+// otherwise callers should check the ref is in the channel they post to.
+func ParseThread(input string) (Ref, error) {
+	input = strings.TrimSpace(input)
+	if strings.Contains(input, "/") {
+		return Parse(input)
+	}
+	if err := validate.Timestamp(input); err != nil {
+		return Ref{}, err
+	}
+	return Ref{TS: validate.NormalizeTimestamp(input)}, nil
+}
+
 func validateConversationID(id string) error {
 	if !conversationIDPattern.MatchString(id) {
 		return fmt.Errorf("invalid conversation ID %q: must start with C, G, or D", id)
@@ -46,6 +46,32 @@ func TestParse(t *testing.T) {
 	}
 }
 
+func TestParseThread(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantChannel string
+		wantTS      string
+		wantErr     bool
+	}{
+		{"1777469221.721439", "", "1777469221.721439", false},
+		{"p1777469221721439", "", "1777469221.721439", false},
+		{"C02DF3BEUGN/1777469221.721439", "C02DF3BEUGN", "1777469221.721439", false},
+		{"https://example.slack.com/archives/C02DF3BEUGN/p1777469221721439", "C02DF3BEUGN", "1777469221.721439", false},
+		{"not-a-ts", "", "", true},
+		{"X02DF3BEUGN/1777469221.721439", "", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseThread(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("ParseThread(%q) err = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if !tt.wantErr && (got.ChannelID != tt.wantChannel || got.TS != tt.wantTS) {
+			t.Errorf("ParseThread(%q) = %+v, want {%s %s}", tt.input, got, tt.wantChannel, tt.wantTS)
+		}
+	}
+}
+
 func TestRefString(t *testing.T) {
 	r := Ref{ChannelID: "C02DF3BEUGN", TS: "1777469221.721439"}
 	want := "C02DF3BEUGN/1777469221.721439"
@@ -3,7 +3,9 @@ package validate
 import (
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // slackURLTimestampRegex matches Slack message URLs and captures the p-prefixed timestamp
@@ -14,8 +16,18 @@ var (
 	channelIDRegex = regexp.MustCompile(`^[CG][A-Z0-9]+$`)
 	userIDRegex    = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
 	timestampRegex = regexp.MustCompile(`^\d+\.\d+$`)
+	relativeRegex  = regexp.MustCompile(`^(\d+)([mhdw])$`)
 )
 
+// timeBoundLayouts are the absolute date formats ParseTimeBound accepts,
+// interpreted in the local time zone unless they carry an offset.
+var timeBoundLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
 // ChannelID validates that the given string is a valid Slack channel ID.
 // Channel IDs start with C (public) or G (private/group).
 func ChannelID(id string) error {
@@ -83,6 +95,43 @@ func Timestamp(ts string) error {
 	return nil
 }
 
+// ParseTimeBound converts a human time bound into a Slack timestamp for use
+// as an oldest/latest cursor. Accepts:
+//   - Slack timestamps in any form NormalizeTimestamp understands
+//   - Unix seconds: "1700000000"
+//   - Relative ages counted back from now: "30m", "12h", "7d", "2w"
+//   - Dates and times: "2026-10-01" (local midnight), "2026-10-01 15:04",
+//     or RFC 3339 ("2026-10-01T15:04:05Z")
+func ParseTimeBound(input string, now time.Time) (string, error) {
+	input = strings.TrimSpace(input)
+	if normalized := NormalizeTimestamp(input); timestampRegex.MatchString(normalized) {
+		return normalized, nil
+	}
+	if secs, err := strconv.ParseInt(input, 10, 64); err == nil {
+		return fmt.Sprintf("%d.000000", secs), nil
+	}
+	if m := relativeRegex.FindStringSubmatch(strings.ToLower(input)); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		unit := map[string]time.Duration{
+			"m": time.Minute,
+			"h": time.Hour,
+			"d": 24 * time.Hour,
+			"w": 7 * 24 * time.Hour,
+		}[m[2]]
+		return formatSlackTime(now.Add(-time.Duration(n) * unit)), nil
+	}
+	for _, layout := range timeBoundLayouts {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return formatSlackTime(t), nil
+		}
+	}
+	return "", fmt.Errorf("invalid time %q: use a duration like 7d or 12h, a date like 2026-10-01, or a Slack timestamp", input)
+}
+
+func formatSlackTime(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
 // Emoji normalizes an emoji name by stripping surrounding colons.
 // Returns the cleaned emoji name.
 func Emoji(emoji string) string {
@@ -2,6 +2,7 @@ package validate
 
 import (
 	"testing"
+	"time"
 )
 
 func TestChannelID(t *testing.T) {
@@ -172,3 +173,41 @@ func TestLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTimeBound(t *testing.T) {
+	loc := time.FixedZone("test", 2*60*60)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"slack timestamp", "1700000000.123456", "1700000000.123456", false},
+		{"p-prefixed", "p1700000000123456", "1700000000.123456", false},
+		{"unix seconds", "1700000000", "1700000000.000000", false},
+		{"minutes", "30m", formatSlackTime(now.Add(-30 * time.Minute)), false},
+		{"hours", "12h", formatSlackTime(now.Add(-12 * time.Hour)), false},
+		{"days", "7d", formatSlackTime(now.AddDate(0, 0, -7)), false},
+		{"weeks", "2W", formatSlackTime(now.AddDate(0, 0, -14)), false},
+		{"date", "2026-10-01", formatSlackTime(time.Date(2026, 10, 1, 0, 0, 0, 0, loc)), false},
+		{"date time", "2026-10-01 15:04", formatSlackTime(time.Date(2026, 10, 1, 15, 4, 0, 0, loc)), false},
+		{"rfc3339", "2026-10-01T00:00:00Z", "1790812800.000000", false},
+		{"garbage", "last tuesday", "", true},
+		{"empty", "", "", true},
+		{"bad unit", "7y", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeBound(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTimeBound(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTimeBound(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}